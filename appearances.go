@@ -0,0 +1,168 @@
+package football
+
+// Lengths of a match in minutes, without added time.
+const (
+	regularLength   = 90
+	extraTimeLength = 120
+)
+
+// Appearance represents the participation of a player in a match. Players
+// that stayed on the bench do not appear.
+type Appearance struct {
+	Player      Player `json:"player"`
+	Team        Team   `json:"team"`
+	Started     bool   `json:"started"`
+	On          int    `json:"on"`  // Minute the player came on, 0 for starters.
+	Off         int    `json:"off"` // Minute the player was substituted or sent off, or the end of the match.
+	Minutes     int    `json:"minutes"`
+	Goals       int    `json:"goals"`
+	OwnGoals    int    `json:"ownGoals"`
+	Assists     int    `json:"assists"`
+	YellowCards int    `json:"yellowCards"`
+	RedCards    int    `json:"redCards"`
+}
+
+// Appearances returns the appearances of every player of the match, built
+// from the lineups, substitutions, goals and bookings. Added time is not
+// counted, so a full match is 90 minutes, or 120 with extra time.
+func (m *Match) Appearances() []Appearance {
+	length := m.Length()
+
+	appearances := []Appearance{}
+	index := map[int64]int{}
+
+	for _, team := range []*Team{m.HomeTeam, m.AwayTeam} {
+		if team == nil || team.Lineup == nil {
+			continue
+		}
+		for _, player := range *team.Lineup {
+			index[player.ID] = len(appearances)
+			appearances = append(appearances, Appearance{
+				Player:  player,
+				Team:    Team{ID: team.ID, Name: team.Name},
+				Started: true,
+				Off:     length,
+			})
+		}
+	}
+
+	capped := func(minute int) int {
+		if minute > length {
+			return length
+		}
+		return minute
+	}
+
+	for _, event := range m.Timeline() {
+		switch {
+		case event.Substitution != nil:
+			if i, ok := index[event.Substitution.PlayerOut.ID]; ok {
+				appearances[i].Off = capped(event.Minute)
+			}
+			index[event.Substitution.PlayerIn.ID] = len(appearances)
+			appearances = append(appearances, Appearance{
+				Player: event.Substitution.PlayerIn,
+				Team:   Team{ID: event.Team.ID, Name: event.Team.Name},
+				On:     capped(event.Minute),
+				Off:    length,
+			})
+
+		case event.Booking != nil:
+			i, ok := index[event.Booking.Player.ID]
+			if !ok {
+				continue
+			}
+			switch event.Booking.Card {
+			case CardRed, CardYellowRed:
+				appearances[i].RedCards++
+				appearances[i].Off = capped(event.Minute)
+			default:
+				appearances[i].YellowCards++
+			}
+
+		case event.Goal != nil:
+			if i, ok := index[event.Goal.Scorer.ID]; ok {
+				if event.Goal.Type == "OWN" {
+					appearances[i].OwnGoals++
+				} else {
+					appearances[i].Goals++
+				}
+			}
+			if i, ok := index[event.Goal.Assist.ID]; ok && event.Goal.Assist.ID != 0 {
+				appearances[i].Assists++
+			}
+		}
+	}
+
+	for i := range appearances {
+		if appearances[i].Off > appearances[i].On {
+			appearances[i].Minutes = appearances[i].Off - appearances[i].On
+		}
+	}
+
+	return appearances
+}
+
+// Length returns the number of minutes of the match without added time,
+// 120 when it went to extra time and 90 otherwise.
+func (m *Match) Length() int {
+	if m.Score != nil && len(m.Score.Duration) > 0 && m.Score.Duration != "REGULAR" {
+		return extraTimeLength
+	}
+	return regularLength
+}
+
+// PlayerStats represents the aggregated numbers of a player over a set of
+// matches.
+type PlayerStats struct {
+	Player      Player `json:"player"`
+	Matches     int    `json:"matches"` // Matches considered, including those on the bench.
+	Appearances int    `json:"appearances"`
+	Starts      int    `json:"starts"`
+	Minutes     int    `json:"minutes"`
+	Goals       int    `json:"goals"`
+	OwnGoals    int    `json:"ownGoals"`
+	Assists     int    `json:"assists"`
+	YellowCards int    `json:"yellowCards"`
+	RedCards    int    `json:"redCards"`
+}
+
+// GoalsPer90 returns the goals scored for every 90 minutes played.
+func (s *PlayerStats) GoalsPer90() float64 {
+	if s.Minutes == 0 {
+		return 0
+	}
+	return float64(s.Goals) * 90 / float64(s.Minutes)
+}
+
+// AggregatePlayerStats adds up the appearances of the player with the
+// given ID in the finished matches. Matches must carry their lineups,
+// substitutions, goals and bookings.
+func AggregatePlayerStats(player Player, matches []Match) *PlayerStats {
+	stats := &PlayerStats{Player: player}
+
+	for _, match := range matches {
+		if match.Status != string(StatusFinished) {
+			continue
+		}
+		stats.Matches++
+
+		for _, appearance := range match.Appearances() {
+			if appearance.Player.ID != player.ID {
+				continue
+			}
+			stats.Appearances++
+			if appearance.Started {
+				stats.Starts++
+			}
+			stats.Minutes += appearance.Minutes
+			stats.Goals += appearance.Goals
+			stats.OwnGoals += appearance.OwnGoals
+			stats.Assists += appearance.Assists
+			stats.YellowCards += appearance.YellowCards
+			stats.RedCards += appearance.RedCards
+		}
+	}
+
+	return stats
+}
@@ -0,0 +1,70 @@
+package football
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_Appearances(t *testing.T) {
+	match := timelineMatch()
+	match.Goals[0].Assist = Player{ID: 1}
+
+	appearances := match.Appearances()
+
+	byPlayer := map[int64]Appearance{}
+	for _, appearance := range appearances {
+		byPlayer[appearance.Player.ID] = appearance
+	}
+
+	assert.Len(t, appearances, 6)
+
+	assert.Equal(t, Appearance{
+		Player:  Player{ID: 1, Name: "Muriel"},
+		Team:    Team{ID: 1765, Name: "Fluminense FC"},
+		Started: true,
+		Off:     120,
+		Minutes: 120,
+		Assists: 1,
+	}, byPlayer[1])
+
+	assert.Equal(t, 60, byPlayer[2].Minutes)
+	assert.Equal(t, 1, byPlayer[2].RedCards)
+
+	assert.Equal(t, 70, byPlayer[3].Off)
+	assert.Equal(t, 70, byPlayer[3].Minutes)
+	assert.Equal(t, 1, byPlayer[3].Goals)
+
+	assert.False(t, byPlayer[4].Started)
+	assert.Equal(t, 70, byPlayer[4].On)
+	assert.Equal(t, 50, byPlayer[4].Minutes)
+	assert.Equal(t, 1, byPlayer[4].Goals)
+
+	assert.Equal(t, 1, byPlayer[11].YellowCards)
+}
+
+func TestAggregatePlayerStats(t *testing.T) {
+	first := timelineMatch()
+	first.Status = string(StatusFinished)
+
+	second := timelineMatch()
+	second.Status = string(StatusFinished)
+	second.Score.Duration = "REGULAR"
+	second.Substitutions = nil
+	second.Bookings = nil
+
+	scheduled := timelineMatch()
+	scheduled.Status = string(StatusScheduled)
+
+	stats := AggregatePlayerStats(Player{ID: 3}, []Match{*first, *second, *scheduled})
+
+	assert.Equal(t, &PlayerStats{
+		Player:      Player{ID: 3},
+		Matches:     2,
+		Appearances: 2,
+		Starts:      2,
+		Minutes:     160,
+		Goals:       2,
+	}, stats)
+	assert.Equal(t, 2*90/160.0, stats.GoalsPer90())
+}
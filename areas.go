@@ -38,7 +38,7 @@ func (s *AreaService) Find(ctx context.Context, id string) (*Area, error) {
 
 	area := &Area{}
 
-	_, err := s.client.Get(fmt.Sprintf("areas/%s", id), nil, &area)
+	_, err := s.client.GetContext(ctx, fmt.Sprintf("areas/%s", id), nil, &area)
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +51,7 @@ func (s *AreaService) Find(ctx context.Context, id string) (*Area, error) {
 func (s *AreaService) List(ctx context.Context) (*AreaList, error) {
 	areas := &AreaList{}
 
-	_, err := s.client.Get("areas", nil, &areas)
+	_, err := s.client.GetContext(ctx, "areas", nil, &areas)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,214 @@
+package football
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AreaTree is the hierarchy of areas, from the world down to countries,
+// built from the ParentAreaID of each area.
+type AreaTree struct {
+	areas    map[int]Area
+	children map[int][]int
+	codes    map[string]int
+	roots    []int
+}
+
+// NewAreaTree builds the tree of the given areas. Areas whose parent is
+// not among them are roots. Child areas nested in an area are added too.
+func NewAreaTree(areas []Area) *AreaTree {
+	t := &AreaTree{
+		areas:    map[int]Area{},
+		children: map[int][]int{},
+		codes:    map[string]int{},
+	}
+
+	var add func(area Area, parent int)
+	add = func(area Area, parent int) {
+		if area.ParentAreaID == 0 {
+			area.ParentAreaID = parent
+		}
+		if area.ChildAreas != nil {
+			for _, child := range *area.ChildAreas {
+				add(child, area.ID)
+			}
+		}
+		if _, ok := t.areas[area.ID]; ok && area.ParentAreaID == 0 {
+			return
+		}
+		t.areas[area.ID] = area
+	}
+	for _, area := range areas {
+		add(area, 0)
+	}
+
+	for id, area := range t.areas {
+		if area.CountryCode != "" {
+			t.codes[strings.ToUpper(area.CountryCode)] = id
+		}
+
+		if _, ok := t.areas[area.ParentAreaID]; ok && area.ParentAreaID != id {
+			t.children[area.ParentAreaID] = append(t.children[area.ParentAreaID], id)
+		} else {
+			t.roots = append(t.roots, id)
+		}
+	}
+
+	for id := range t.children {
+		t.sort(t.children[id])
+	}
+	t.sort(t.roots)
+
+	return t
+}
+
+// Tree returns the hierarchy of all areas.
+func (s *AreaService) Tree(ctx context.Context) (*AreaTree, error) {
+	areas, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAreaTree(areas.Areas), nil
+}
+
+// Area returns the area with the given ID.
+func (t *AreaTree) Area(id int) (Area, bool) {
+	area, ok := t.areas[id]
+	return area, ok
+}
+
+// ByCountryCode returns the area with the given country code, such as
+// "BRA" or "ENG", ignoring case.
+func (t *AreaTree) ByCountryCode(code string) (Area, bool) {
+	id, ok := t.codes[strings.ToUpper(code)]
+	if !ok {
+		return Area{}, false
+	}
+	return t.areas[id], true
+}
+
+// Roots returns the areas without a parent, sorted by name.
+func (t *AreaTree) Roots() []Area {
+	return t.list(t.roots)
+}
+
+// Parent returns the parent of the area.
+func (t *AreaTree) Parent(id int) (Area, bool) {
+	area, ok := t.areas[id]
+	if !ok || area.ParentAreaID == id {
+		return Area{}, false
+	}
+
+	parent, ok := t.areas[area.ParentAreaID]
+	return parent, ok
+}
+
+// Children returns the direct children of the area, sorted by name.
+func (t *AreaTree) Children(id int) []Area {
+	return t.list(t.children[id])
+}
+
+// Ancestors returns the parents of the area, nearest first.
+func (t *AreaTree) Ancestors(id int) []Area {
+	ancestors := []Area{}
+	seen := map[int]bool{id: true}
+
+	for {
+		parent, ok := t.Parent(id)
+		if !ok || seen[parent.ID] {
+			return ancestors
+		}
+		seen[parent.ID] = true
+		ancestors = append(ancestors, parent)
+		id = parent.ID
+	}
+}
+
+// Descendants returns every area under the area, level by level.
+func (t *AreaTree) Descendants(id int) []Area {
+	ids := []int{}
+	seen := map[int]bool{id: true}
+	queue := append([]int{}, t.children[id]...)
+
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if seen[next] {
+			continue
+		}
+		seen[next] = true
+		ids = append(ids, next)
+		queue = append(queue, t.children[next]...)
+	}
+
+	return t.list(ids)
+}
+
+// Contains reports whether the area id is the area ancestor or one of
+// its descendants.
+func (t *AreaTree) Contains(ancestor, id int) bool {
+	if ancestor == id {
+		_, ok := t.areas[id]
+		return ok
+	}
+
+	for _, area := range t.Ancestors(id) {
+		if area.ID == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the area and its descendants as the comma separated IDs
+// CompetitionFiltersOptions.Areas expects.
+func (t *AreaTree) Filter(id int) string {
+	ids := []string{strconv.Itoa(id)}
+	for _, area := range t.Descendants(id) {
+		ids = append(ids, strconv.Itoa(area.ID))
+	}
+	return strings.Join(ids, ",")
+}
+
+// Competitions returns the competitions whose area is under the area.
+func (t *AreaTree) Competitions(id int, competitions []Competition) []Competition {
+	under := []Competition{}
+	for _, competition := range competitions {
+		if t.Contains(id, competition.Area.ID) {
+			under = append(under, competition)
+		}
+	}
+	return under
+}
+
+// Teams returns the teams whose area is under the area.
+func (t *AreaTree) Teams(id int, teams []Team) []Team {
+	under := []Team{}
+	for _, team := range teams {
+		if team.Area != nil && t.Contains(id, team.Area.ID) {
+			under = append(under, team)
+		}
+	}
+	return under
+}
+
+func (t *AreaTree) list(ids []int) []Area {
+	areas := make([]Area, len(ids))
+	for i, id := range ids {
+		areas[i] = t.areas[id]
+	}
+	return areas
+}
+
+func (t *AreaTree) sort(ids []int) {
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := t.areas[ids[i]], t.areas[ids[j]]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+}
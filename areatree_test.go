@@ -0,0 +1,134 @@
+package football
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testAreaTree() *AreaTree {
+	return NewAreaTree([]Area{
+		{ID: 2267, Name: "World"},
+		{ID: 2077, Name: "Europe", CountryCode: "EUR", ParentAreaID: 2267},
+		{ID: 2220, Name: "South America", CountryCode: "SAM", ParentAreaID: 2267},
+		{ID: 2072, Name: "England", CountryCode: "ENG", ParentAreaID: 2077},
+		{ID: 2224, Name: "Spain", CountryCode: "ESP", ParentAreaID: 2077},
+		{ID: 2032, Name: "Brazil", CountryCode: "BRA", ParentAreaID: 2220},
+		{ID: 2011, Name: "Argentina", CountryCode: "ARG", ParentAreaID: 2220},
+	})
+}
+
+func areaIDs(areas []Area) []int {
+	ids := []int{}
+	for _, area := range areas {
+		ids = append(ids, area.ID)
+	}
+	return ids
+}
+
+func TestAreaTree_Lookups(t *testing.T) {
+	tree := testAreaTree()
+
+	assert.Equal(t, []int{2267}, areaIDs(tree.Roots()))
+	assert.Equal(t, []int{2077, 2220}, areaIDs(tree.Children(2267)))
+	assert.Equal(t, []int{2011, 2032}, areaIDs(tree.Children(2220)))
+	assert.Empty(t, tree.Children(2032))
+
+	parent, ok := tree.Parent(2032)
+	assert.True(t, ok)
+	assert.Equal(t, "South America", parent.Name)
+
+	_, ok = tree.Parent(2267)
+	assert.False(t, ok)
+
+	assert.Equal(t, []int{2220, 2267}, areaIDs(tree.Ancestors(2032)))
+	assert.Equal(t, []int{2077, 2220, 2072, 2224, 2011, 2032}, areaIDs(tree.Descendants(2267)))
+
+	brazil, ok := tree.ByCountryCode("bra")
+	assert.True(t, ok)
+	assert.Equal(t, 2032, brazil.ID)
+
+	_, ok = tree.ByCountryCode("XYZ")
+	assert.False(t, ok)
+
+	assert.True(t, tree.Contains(2077, 2072))
+	assert.True(t, tree.Contains(2072, 2072))
+	assert.False(t, tree.Contains(2220, 2072))
+	assert.False(t, tree.Contains(1, 1))
+
+	assert.Equal(t, "2220,2011,2032", tree.Filter(2220))
+}
+
+func TestAreaTree_ChildAreas(t *testing.T) {
+	tree := NewAreaTree([]Area{
+		{ID: 2077, Name: "Europe", ChildAreas: &[]Area{
+			{ID: 2072, Name: "England"},
+			{ID: 2224, Name: "Spain"},
+		}},
+	})
+
+	assert.Equal(t, []int{2072, 2224}, areaIDs(tree.Children(2077)))
+
+	parent, ok := tree.Parent(2224)
+	assert.True(t, ok)
+	assert.Equal(t, 2077, parent.ID)
+}
+
+func TestAreaTree_Cycle(t *testing.T) {
+	tree := NewAreaTree([]Area{
+		{ID: 1, Name: "A", ParentAreaID: 2},
+		{ID: 2, Name: "B", ParentAreaID: 1},
+	})
+
+	assert.Equal(t, []int{2}, areaIDs(tree.Ancestors(1)))
+	assert.Equal(t, []int{2}, areaIDs(tree.Descendants(1)))
+}
+
+func TestAreaTree_Filtering(t *testing.T) {
+	tree := testAreaTree()
+
+	competitions := []Competition{
+		{ID: 2021, Code: "PL", Area: Area{ID: 2072}},
+		{ID: 2013, Code: "BSA", Area: Area{ID: 2032}},
+		{ID: 2014, Code: "PD", Area: Area{ID: 2224}},
+		{ID: 2000, Code: "WC", Area: Area{ID: 2267}},
+	}
+
+	europe := tree.Competitions(2077, competitions)
+	assert.Len(t, europe, 2)
+	assert.Equal(t, "PL", europe[0].Code)
+	assert.Equal(t, "PD", europe[1].Code)
+
+	teams := []Team{
+		{ID: 1783, Name: "CR Flamengo", Area: &Area{ID: 2032}},
+		{ID: 57, Name: "Arsenal FC", Area: &Area{ID: 2072}},
+		{ID: 1, Name: "Unknown"},
+	}
+
+	southAmerica := tree.Teams(2220, teams)
+	assert.Len(t, southAmerica, 1)
+	assert.Equal(t, 1783, southAmerica[0].ID)
+}
+
+func TestAreaService_Tree(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/areas", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		fmt.Fprint(w, `{"count": 3, "areas": [
+			{"id": 2267, "name": "World", "countryCode": "INT", "parentAreaId": null},
+			{"id": 2220, "name": "South America", "countryCode": "SAM", "parentAreaId": 2267, "parentArea": "World"},
+			{"id": 2032, "name": "Brazil", "countryCode": "BRA", "parentAreaId": 2220, "parentArea": "South America"}
+		]}`)
+	})
+
+	client := NewClient(httpClient)
+	tree, err := client.Areas.Tree(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2220, 2267}, areaIDs(tree.Ancestors(2032)))
+}
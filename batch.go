@@ -0,0 +1,86 @@
+package football
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultConcurrency is the number of requests FindMany runs at once when
+// Client.Concurrency is not set.
+const DefaultConcurrency = 4
+
+// TeamResult is the outcome of fetching one team with FindMany.
+type TeamResult struct {
+	ID   string
+	Team *Team
+	Err  error
+}
+
+// MatchResult is the outcome of fetching one match with FindMany.
+type MatchResult struct {
+	ID    string
+	Match *MatchResponse
+	Err   error
+}
+
+// FindMany takes Team IDs and returns the corresponding Teams, in the order
+// of ids. Failures are reported per team, so the other teams are still
+// returned. Requests run concurrently, up to Client.Concurrency at once,
+// and wait for the client rate limiter.
+func (s *TeamService) FindMany(ctx context.Context, ids []string) []TeamResult {
+	results := make([]TeamResult, len(ids))
+
+	s.client.forEach(ctx, len(ids), func(ctx context.Context, i int) {
+		results[i].ID = ids[i]
+		results[i].Team, results[i].Err = s.Find(ctx, ids[i])
+	})
+
+	return results
+}
+
+// FindMany takes Match IDs and returns the corresponding Matches, in the
+// order of ids. Failures are reported per match, so the other matches are
+// still returned. Requests run concurrently, up to Client.Concurrency at
+// once, and wait for the client rate limiter.
+func (s *MatchService) FindMany(ctx context.Context, ids []string) []MatchResult {
+	results := make([]MatchResult, len(ids))
+
+	s.client.forEach(ctx, len(ids), func(ctx context.Context, i int) {
+		results[i].ID = ids[i]
+		results[i].Match, results[i].Err = s.Find(ctx, ids[i])
+	})
+
+	return results
+}
+
+// forEach calls fn for 0 to n-1, running up to c.Concurrency calls at once.
+// Once ctx is done the remaining calls still run and fail fast.
+func (c *Client) forEach(ctx context.Context, n int, fn func(ctx context.Context, i int)) {
+	workers := c.Concurrency
+	if workers <= 0 {
+		workers = DefaultConcurrency
+	}
+	if workers > n {
+		workers = n
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(ctx, i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+}
@@ -0,0 +1,85 @@
+package football
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamService_FindMany(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	var running, maxRunning int32
+	mux.HandleFunc("/v2/teams/", func(w http.ResponseWriter, r *http.Request) {
+		now := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if now <= max || atomic.CompareAndSwapInt32(&maxRunning, max, now) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		id := strings.TrimPrefix(r.URL.Path, "/v2/teams/")
+		if id == "404" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"id": %s}`, id)
+	})
+
+	client := NewClient(httpClient)
+	client.Concurrency = 2
+
+	results := client.Teams.FindMany(context.Background(), []string{"1", "2", "404", "4", "5"})
+
+	assert.Len(t, results, 5)
+	for i, id := range []int{1, 2, 0, 4, 5} {
+		if id == 0 {
+			assert.Nil(t, results[i].Team)
+			assert.True(t, ErrorContains(results[i].Err, "404"))
+			continue
+		}
+		assert.Nil(t, results[i].Err)
+		assert.Equal(t, id, results[i].Team.ID)
+	}
+	assert.Equal(t, "404", results[2].ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+}
+
+func TestMatchService_FindMany(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/matches/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"match": {"id": %s}}`, strings.TrimPrefix(r.URL.Path, "/v2/matches/"))
+	})
+
+	client := NewClient(httpClient)
+	results := client.Matches.FindMany(context.Background(), []string{"3", "1", "", "2"})
+
+	assert.Equal(t, 3, results[0].Match.Match.ID)
+	assert.Equal(t, 1, results[1].Match.Match.ID)
+	assert.True(t, ErrorContains(results[2].Err, "Match ID is required"))
+	assert.Equal(t, 2, results[3].Match.Match.ID)
+}
+
+func TestMatchService_FindManyCanceled(t *testing.T) {
+	client := NewClient(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := client.Matches.FindMany(ctx, []string{"1", "2"})
+
+	for _, result := range results {
+		assert.Equal(t, context.Canceled, result.Err)
+	}
+}
@@ -0,0 +1,85 @@
+package bracket
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// WriteASCII renders the bracket round by round as plain text:
+//
+//	QUARTER_FINALS
+//	  Chelsea FC           2-1  Real Madrid CF        -> Chelsea FC (AGGREGATE)
+func (b *Bracket) WriteASCII(w io.Writer) error {
+	width := 0
+	for _, round := range b.Rounds {
+		for _, tie := range round.Ties {
+			width = maxInt(width, len(name(tie.Home)), len(name(tie.Away)))
+		}
+	}
+
+	for i, round := range b.Rounds {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w, round.Stage); err != nil {
+			return err
+		}
+
+		for _, tie := range round.Ties {
+			line := fmt.Sprintf("  %-*s  %s  %-*s", width, name(tie.Home), score(tie), width, name(tie.Away))
+			if tie.Winner != nil {
+				line += fmt.Sprintf("  -> %s", name(tie.Winner))
+				if len(tie.DecidedBy) > 0 {
+					line += fmt.Sprintf(" (%s)", tie.DecidedBy)
+				}
+			}
+			if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns the ASCII rendering of the bracket.
+func (b *Bracket) String() string {
+	out := &strings.Builder{}
+	b.WriteASCII(out)
+	return out.String()
+}
+
+func score(tie *Tie) string {
+	for _, leg := range tie.Legs {
+		if leg.Status == string(football.StatusFinished) || leg.Status == string(football.StatusInPlay) || leg.Status == string(football.StatusPaused) {
+			s := fmt.Sprintf("%d-%d", tie.Aggregate.HomeTeam, tie.Aggregate.AwayTeam)
+			if tie.Penalties != (football.Time{}) {
+				s += fmt.Sprintf(" (%d-%d p)", tie.Penalties.HomeTeam, tie.Penalties.AwayTeam)
+			}
+			return s
+		}
+	}
+	return "v"
+}
+
+func name(team *football.Team) string {
+	if team == nil || len(team.Name) == 0 {
+		return "TBD"
+	}
+	return team.Name
+}
+
+func maxInt(values ...int) int {
+	m := 0
+	for _, v := range values {
+		if v > m {
+			m = v
+		}
+	}
+	return m
+}
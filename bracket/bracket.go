@@ -0,0 +1,296 @@
+// Package bracket builds knockout brackets for cup competitions from the
+// flat list of matches returned by CompetitionService.Matches.
+package bracket
+
+import (
+	"fmt"
+	"sort"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// Stages lists the knockout stages in the order they are played.
+var Stages = []string{
+	"PRELIMINARY_ROUND",
+	"PRELIMINARY_SEMI_FINALS",
+	"PRELIMINARY_FINAL",
+	"1ST_QUALIFYING_ROUND",
+	"2ND_QUALIFYING_ROUND",
+	"3RD_QUALIFYING_ROUND",
+	"PLAY_OFF_ROUND",
+	"PLAY_OFF_ROUND_ONE",
+	"PLAY_OFF_ROUND_TWO",
+	"PLAYOFFS",
+	"ROUND_OF_16",
+	"LAST_16",
+	"QUARTER_FINALS",
+	"SEMI_FINALS",
+	"THIRD_PLACE",
+	"FINAL",
+}
+
+// nonKnockout lists the stages that are not part of a bracket.
+var nonKnockout = map[string]bool{
+	"":               true,
+	"REGULAR_SEASON": true,
+	"GROUP_STAGE":    true,
+	"LEAGUE_STAGE":   true,
+}
+
+// Ways a tie can be decided.
+const (
+	DecidedByAggregate = "AGGREGATE"
+	DecidedByAwayGoals = "AWAY_GOALS"
+	DecidedByExtraTime = "EXTRA_TIME"
+	DecidedByPenalties = "PENALTIES"
+)
+
+// Options controls how ties are decided.
+type Options struct {
+	// AwayGoals breaks level aggregates of two-legged ties with the away
+	// goals rule, used by UEFA competitions until 2021.
+	AwayGoals bool
+}
+
+// Bracket holds the knockout rounds of a competition.
+type Bracket struct {
+	Rounds []*Round `json:"rounds"`
+}
+
+// Round holds the ties of a stage.
+type Round struct {
+	Stage string `json:"stage"`
+	Ties  []*Tie `json:"ties"`
+}
+
+// Tie is a pairing of two teams over one or two legs. Home is the home
+// team of the first leg.
+type Tie struct {
+	ID        string           `json:"id"`
+	Stage     string           `json:"stage"`
+	Home      *football.Team   `json:"home,omitempty"`
+	Away      *football.Team   `json:"away,omitempty"`
+	Legs      []football.Match `json:"legs"`
+	Aggregate football.Time    `json:"aggregate"`
+	AwayGoals football.Time    `json:"awayGoals"`
+	Penalties football.Time    `json:"penalties"`
+	Winner    *football.Team   `json:"winner,omitempty"`
+	DecidedBy string           `json:"decidedBy,omitempty"`
+	NextTieID string           `json:"nextTieId,omitempty"`
+	Next      *Tie             `json:"-"`
+}
+
+// Finished reports whether every leg of the tie has been played.
+func (t *Tie) Finished() bool {
+	for _, leg := range t.Legs {
+		if leg.Status != string(football.StatusFinished) {
+			return false
+		}
+	}
+	return len(t.Legs) > 0
+}
+
+// Build groups the knockout matches into ties, decides the finished ties
+// and links each tie to the one its winner plays next.
+func Build(matches []football.Match, opts *Options) *Bracket {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	byStage := map[string][]football.Match{}
+	stages := []string{}
+	for _, match := range matches {
+		if nonKnockout[match.Stage] {
+			continue
+		}
+		if _, ok := byStage[match.Stage]; !ok {
+			stages = append(stages, match.Stage)
+		}
+		byStage[match.Stage] = append(byStage[match.Stage], match)
+	}
+
+	sort.SliceStable(stages, func(i, j int) bool {
+		return stageOrder(stages[i]) < stageOrder(stages[j])
+	})
+
+	b := &Bracket{Rounds: []*Round{}}
+	for _, stage := range stages {
+		b.Rounds = append(b.Rounds, buildRound(stage, byStage[stage], opts))
+	}
+	b.link()
+
+	return b
+}
+
+func stageOrder(stage string) int {
+	for i, s := range Stages {
+		if s == stage {
+			return i
+		}
+	}
+	return len(Stages)
+}
+
+func buildRound(stage string, matches []football.Match, opts *Options) *Round {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].UtcDate < matches[j].UtcDate
+	})
+
+	round := &Round{Stage: stage, Ties: []*Tie{}}
+	byPair := map[string]*Tie{}
+
+	for _, match := range matches {
+		key := pairKey(match)
+		tie, ok := byPair[key]
+		if !ok {
+			tie = &Tie{
+				ID:    fmt.Sprintf("%s-%d", stage, len(round.Ties)+1),
+				Stage: stage,
+				Home:  match.HomeTeam,
+				Away:  match.AwayTeam,
+			}
+			byPair[key] = tie
+			round.Ties = append(round.Ties, tie)
+		}
+		tie.Legs = append(tie.Legs, match)
+	}
+
+	for _, tie := range round.Ties {
+		tie.decide(opts)
+	}
+
+	return round
+}
+
+// pairKey identifies the two teams of a match regardless of who plays at
+// home. Matches between teams still to be decided are never paired.
+func pairKey(match football.Match) string {
+	if match.HomeTeam == nil || match.AwayTeam == nil || match.HomeTeam.ID == 0 || match.AwayTeam.ID == 0 {
+		return fmt.Sprintf("match-%d", match.ID)
+	}
+
+	a, b := match.HomeTeam.ID, match.AwayTeam.ID
+	if a > b {
+		a, b = b, a
+	}
+	return fmt.Sprintf("%d-%d", a, b)
+}
+
+// decide computes the aggregate of the tie and its winner.
+func (t *Tie) decide(opts *Options) {
+	regular := football.Time{}
+	for _, leg := range t.Legs {
+		if leg.Score == nil || leg.HomeTeam == nil || t.Home == nil {
+			continue
+		}
+
+		full := leg.Score.FullTime
+		full.HomeTeam -= leg.Score.Penalties.HomeTeam
+		full.AwayTeam -= leg.Score.Penalties.AwayTeam
+
+		home, away := full.HomeTeam, full.AwayTeam
+		extraHome, extraAway := leg.Score.ExtraTime.HomeTeam, leg.Score.ExtraTime.AwayTeam
+		if leg.HomeTeam.ID != t.Home.ID {
+			home, away = away, home
+			extraHome, extraAway = extraAway, extraHome
+			t.AwayGoals.HomeTeam += home
+		} else {
+			t.AwayGoals.AwayTeam += away
+		}
+
+		t.Aggregate.HomeTeam += home
+		t.Aggregate.AwayTeam += away
+		regular.HomeTeam += home - extraHome
+		regular.AwayTeam += away - extraAway
+
+		if leg.Score.Penalties != (football.Time{}) {
+			t.Penalties = leg.Score.Penalties
+			if leg.HomeTeam.ID != t.Home.ID {
+				t.Penalties = football.Time{HomeTeam: leg.Score.Penalties.AwayTeam, AwayTeam: leg.Score.Penalties.HomeTeam}
+			}
+		}
+	}
+
+	if !t.Finished() || t.Home == nil || t.Away == nil {
+		return
+	}
+
+	switch {
+	case t.Aggregate.HomeTeam != t.Aggregate.AwayTeam:
+		t.DecidedBy = DecidedByAggregate
+		if regular.HomeTeam == regular.AwayTeam {
+			t.DecidedBy = DecidedByExtraTime
+		}
+		t.Winner = t.pick(t.Aggregate.HomeTeam > t.Aggregate.AwayTeam)
+
+	case opts.AwayGoals && len(t.Legs) == 2 && t.AwayGoals.HomeTeam != t.AwayGoals.AwayTeam:
+		t.DecidedBy = DecidedByAwayGoals
+		t.Winner = t.pick(t.AwayGoals.HomeTeam > t.AwayGoals.AwayTeam)
+
+	case t.Penalties.HomeTeam != t.Penalties.AwayTeam:
+		t.DecidedBy = DecidedByPenalties
+		t.Winner = t.pick(t.Penalties.HomeTeam > t.Penalties.AwayTeam)
+
+	default:
+		// Fall back to the winner reported for the last leg.
+		last := t.Legs[len(t.Legs)-1]
+		if last.Score == nil || last.HomeTeam == nil {
+			return
+		}
+		switch last.Score.Winner {
+		case "HOME_TEAM":
+			t.Winner = t.pick(last.HomeTeam.ID == t.Home.ID)
+		case "AWAY_TEAM":
+			t.Winner = t.pick(last.HomeTeam.ID != t.Home.ID)
+		}
+	}
+}
+
+func (t *Tie) pick(home bool) *football.Team {
+	if home {
+		return t.Home
+	}
+	return t.Away
+}
+
+// link points every decided tie to the tie its winner plays in a later
+// round. The third place play-off is never a next tie.
+func (b *Bracket) link() {
+	for i, round := range b.Rounds {
+		for _, tie := range round.Ties {
+			if tie.Winner == nil {
+				continue
+			}
+			tie.Next = b.find(tie.Winner.ID, i+1)
+			if tie.Next != nil {
+				tie.NextTieID = tie.Next.ID
+			}
+		}
+	}
+}
+
+func (b *Bracket) find(team, from int) *Tie {
+	for _, round := range b.Rounds[from:] {
+		if round.Stage == "THIRD_PLACE" {
+			continue
+		}
+		for _, tie := range round.Ties {
+			if (tie.Home != nil && tie.Home.ID == team) || (tie.Away != nil && tie.Away.ID == team) {
+				return tie
+			}
+		}
+	}
+	return nil
+}
+
+// Tie returns the tie with the given ID.
+func (b *Bracket) Tie(id string) *Tie {
+	for _, round := range b.Rounds {
+		for _, tie := range round.Ties {
+			if tie.ID == id {
+				return tie
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package bracket
+
+import (
+	"encoding/json"
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	chelsea  = &football.Team{ID: 61, Name: "Chelsea FC"}
+	real     = &football.Team{ID: 86, Name: "Real Madrid CF"}
+	city     = &football.Team{ID: 65, Name: "Manchester City FC"}
+	psg      = &football.Team{ID: 524, Name: "Paris Saint-Germain FC"}
+	porto    = &football.Team{ID: 503, Name: "FC Porto"}
+	dortmund = &football.Team{ID: 4, Name: "Borussia Dortmund"}
+)
+
+func leg(id int, stage, date string, home *football.Team, homeGoals, awayGoals int, away *football.Team) football.Match {
+	return football.Match{
+		ID:       id,
+		Stage:    stage,
+		UtcDate:  date,
+		Status:   string(football.StatusFinished),
+		HomeTeam: home,
+		AwayTeam: away,
+		Score: &football.Score{
+			Duration: "REGULAR",
+			FullTime: football.Time{HomeTeam: homeGoals, AwayTeam: awayGoals},
+		},
+	}
+}
+
+func matches() []football.Match {
+	final := leg(9, "FINAL", "2021-05-29T19:00:00Z", city, 0, 1, chelsea)
+
+	return []football.Match{
+		leg(1, "GROUP_STAGE", "2020-10-20T19:00:00Z", chelsea, 0, 0, psg),
+		final,
+		leg(5, "SEMI_FINALS", "2021-04-27T19:00:00Z", real, 1, 1, chelsea),
+		leg(6, "SEMI_FINALS", "2021-04-28T19:00:00Z", psg, 1, 2, city),
+		leg(7, "SEMI_FINALS", "2021-05-04T19:00:00Z", city, 2, 0, psg),
+		leg(8, "SEMI_FINALS", "2021-05-05T19:00:00Z", chelsea, 2, 0, real),
+		leg(2, "QUARTER_FINALS", "2021-04-07T19:00:00Z", porto, 0, 2, chelsea),
+		leg(3, "QUARTER_FINALS", "2021-04-13T19:00:00Z", chelsea, 0, 1, porto),
+		leg(4, "QUARTER_FINALS", "2021-04-06T19:00:00Z", city, 2, 1, dortmund),
+		leg(10, "QUARTER_FINALS", "2021-04-14T19:00:00Z", dortmund, 1, 2, city),
+	}
+}
+
+func TestBuild(t *testing.T) {
+	b := Build(matches(), nil)
+
+	assert.Len(t, b.Rounds, 3)
+	assert.Equal(t, "QUARTER_FINALS", b.Rounds[0].Stage)
+	assert.Equal(t, "SEMI_FINALS", b.Rounds[1].Stage)
+	assert.Equal(t, "FINAL", b.Rounds[2].Stage)
+
+	quarter := b.Rounds[0].Ties
+	assert.Len(t, quarter, 2)
+	assert.Equal(t, city, quarter[0].Home)
+	assert.Equal(t, football.Time{HomeTeam: 4, AwayTeam: 2}, quarter[0].Aggregate)
+	assert.Equal(t, porto, quarter[1].Home)
+	assert.Equal(t, football.Time{HomeTeam: 1, AwayTeam: 2}, quarter[1].Aggregate)
+	assert.Equal(t, chelsea, quarter[1].Winner)
+	assert.Equal(t, DecidedByAggregate, quarter[1].DecidedBy)
+
+	semi := b.Tie(quarter[1].NextTieID)
+	assert.Equal(t, semi, quarter[1].Next)
+	assert.Equal(t, real, semi.Home)
+	assert.Equal(t, chelsea, semi.Winner)
+	assert.Equal(t, "FINAL-1", semi.NextTieID)
+
+	final := b.Tie("FINAL-1")
+	assert.Equal(t, chelsea, final.Winner)
+	assert.Nil(t, final.Next)
+}
+
+func TestBuild_AwayGoals(t *testing.T) {
+	legs := []football.Match{
+		leg(1, "ROUND_OF_16", "2021-02-16T20:00:00Z", porto, 2, 1, dortmund),
+		leg(2, "ROUND_OF_16", "2021-03-09T20:00:00Z", dortmund, 1, 0, porto),
+	}
+
+	withoutRule := Build(legs, nil).Rounds[0].Ties[0]
+	assert.Equal(t, football.Time{HomeTeam: 2, AwayTeam: 2}, withoutRule.Aggregate)
+	assert.Nil(t, withoutRule.Winner)
+
+	withRule := Build(legs, &Options{AwayGoals: true}).Rounds[0].Ties[0]
+	assert.Equal(t, football.Time{HomeTeam: 0, AwayTeam: 1}, withRule.AwayGoals)
+	assert.Equal(t, dortmund, withRule.Winner)
+	assert.Equal(t, DecidedByAwayGoals, withRule.DecidedBy)
+
+	legs[1].Score.FullTime = football.Time{HomeTeam: 2, AwayTeam: 1}
+	level := Build(legs, &Options{AwayGoals: true}).Rounds[0].Ties[0]
+	assert.Equal(t, football.Time{HomeTeam: 3, AwayTeam: 3}, level.Aggregate)
+	assert.Nil(t, level.Winner)
+}
+
+func TestBuild_ExtraTimeAndPenalties(t *testing.T) {
+	extraTime := leg(1, "FINAL", "2021-07-11T19:00:00Z", city, 2, 1, psg)
+	extraTime.Score.Duration = "EXTRA_TIME"
+	extraTime.Score.ExtraTime = football.Time{HomeTeam: 1}
+
+	tie := Build([]football.Match{extraTime}, nil).Rounds[0].Ties[0]
+	assert.Equal(t, city, tie.Winner)
+	assert.Equal(t, DecidedByExtraTime, tie.DecidedBy)
+
+	shootout := leg(1, "FINAL", "2021-07-11T19:00:00Z", city, 4, 5, psg)
+	shootout.Score.Duration = "PENALTY_SHOOTOUT"
+	shootout.Score.Penalties = football.Time{HomeTeam: 3, AwayTeam: 4}
+
+	tie = Build([]football.Match{shootout}, nil).Rounds[0].Ties[0]
+	assert.Equal(t, football.Time{HomeTeam: 1, AwayTeam: 1}, tie.Aggregate)
+	assert.Equal(t, football.Time{HomeTeam: 3, AwayTeam: 4}, tie.Penalties)
+	assert.Equal(t, psg, tie.Winner)
+	assert.Equal(t, DecidedByPenalties, tie.DecidedBy)
+}
+
+func TestBuild_Scheduled(t *testing.T) {
+	first := leg(1, "SEMI_FINALS", "2021-04-27T19:00:00Z", real, 1, 1, chelsea)
+	second := leg(2, "SEMI_FINALS", "2021-05-05T19:00:00Z", chelsea, 0, 0, real)
+	second.Status = string(football.StatusScheduled)
+	final := football.Match{ID: 3, Stage: "FINAL", Status: string(football.StatusScheduled), HomeTeam: &football.Team{}, AwayTeam: &football.Team{}}
+
+	b := Build([]football.Match{first, second, final}, nil)
+
+	semi := b.Rounds[0].Ties[0]
+	assert.Len(t, semi.Legs, 2)
+	assert.False(t, semi.Finished())
+	assert.Nil(t, semi.Winner)
+	assert.Len(t, b.Rounds[1].Ties, 1)
+}
+
+func TestBracket_JSON(t *testing.T) {
+	b := Build(matches(), nil)
+
+	out, err := json.Marshal(b)
+	assert.Nil(t, err)
+
+	decoded := Bracket{}
+	assert.Nil(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, "SEMI_FINALS-1", decoded.Rounds[0].Ties[1].NextTieID)
+}
+
+func TestBracket_String(t *testing.T) {
+	b := Build(matches(), nil)
+
+	expected := `QUARTER_FINALS
+  Manchester City FC      4-2  Borussia Dortmund       -> Manchester City FC (AGGREGATE)
+  FC Porto                1-2  Chelsea FC              -> Chelsea FC (AGGREGATE)
+
+SEMI_FINALS
+  Real Madrid CF          1-3  Chelsea FC              -> Chelsea FC (AGGREGATE)
+  Paris Saint-Germain FC  1-4  Manchester City FC      -> Manchester City FC (AGGREGATE)
+
+FINAL
+  Manchester City FC      0-1  Chelsea FC              -> Chelsea FC (AGGREGATE)
+`
+	assert.Equal(t, expected, b.String())
+}
@@ -0,0 +1,50 @@
+// Command football-proxy serves the Football Data API paths from a shared
+// cache, so several services share the token in FOOTBALL_API_TOKEN and its
+// quota. Usage per consumer is served at /stats, to the admin token and
+// the configured consumers.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/matheustex/football-data-sdk/proxy"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	ttl := flag.Duration("ttl", time.Minute, "time responses are cached")
+	rate := flag.Int("rate", 10, "upstream requests allowed per minute")
+	consumers := flag.String("consumers", "", "comma separated token=name pairs of the allowed consumers (default: anyone)")
+	adminToken := flag.String("admin-token", os.Getenv("FOOTBALL_PROXY_ADMIN_TOKEN"), "X-Auth-Token giving access to /stats")
+	flag.Parse()
+
+	client := football.NewClient(nil)
+	client.RateLimiter = football.NewRateLimiter(*rate, time.Minute)
+
+	options := &proxy.Options{
+		TTL: *ttl,
+		// Areas barely change.
+		TTLs:       map[string]time.Duration{"areas": 24 * time.Hour},
+		Consumers:  map[string]string{},
+		AdminToken: *adminToken,
+	}
+
+	if *consumers != "" {
+		for _, pair := range strings.Split(*consumers, ",") {
+			parts := strings.SplitN(pair, "=", 2)
+			if len(parts) != 2 {
+				log.Fatalf("invalid consumer %q, want token=name", pair)
+			}
+			options.Consumers[parts[0]] = parts[1]
+		}
+	}
+
+	log.Printf("listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, proxy.New(client, options)))
+}
@@ -0,0 +1,50 @@
+// Command football-webhooks polls the Football Data API and fans match
+// events out to the webhooks registered on its HTTP endpoint.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/matheustex/football-data-sdk/webhook"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	interval := flag.Duration("interval", time.Minute, "time between polls")
+	competitions := flag.String("competitions", "", "comma separated competition IDs to poll")
+	deadLetters := flag.String("dead-letters", "dead-letters.jsonl", "file receiving failed deliveries")
+	adminToken := flag.String("admin-token", os.Getenv("FOOTBALL_WEBHOOKS_TOKEN"), "bearer token required to manage subscriptions")
+	flag.Parse()
+
+	file, err := os.OpenFile(*deadLetters, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	hub := webhook.NewHub(&webhook.Deliverer{
+		DeadLetters: webhook.NewJSONDeadLetterLog(file),
+	})
+	if *adminToken != "" {
+		hub.Authorize = webhook.BearerToken(*adminToken)
+	} else {
+		log.Print("no -admin-token: anyone reaching the server can manage subscriptions")
+	}
+
+	watcher := &webhook.Watcher{
+		Client:   football.NewClient(nil),
+		Hub:      hub,
+		Filters:  &football.MatchesFiltersOptions{Competitions: *competitions},
+		Interval: *interval,
+	}
+	go watcher.Run(context.Background())
+
+	log.Printf("listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, hub))
+}
@@ -0,0 +1,42 @@
+// Command football groups tools working with the Football Data API.
+//
+// Usage:
+//
+//	football snapshot [flags]           capture API responses for offline use
+//	football schema-check <snapshot>... compare recorded responses to the Go structs
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+var commands = map[string]func(args []string) error{
+	"snapshot":     runSnapshot,
+	"schema-check": runSchemaCheck,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	command, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+	}
+
+	if err := command(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "football %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: football <command> [flags]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  snapshot        capture API responses for offline use")
+	fmt.Fprintln(os.Stderr, "  schema-check    compare recorded responses to the Go structs")
+	os.Exit(2)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// endpointReport gathers the drifts of the responses of one endpoint.
+type endpointReport struct {
+	responses int
+	drifts    map[football.Drift]int
+}
+
+// runSchemaCheck compares the responses recorded in snapshots to the Go
+// structs and prints the drifts found per endpoint. It fails when any
+// endpoint drifted.
+func runSchemaCheck(args []string) error {
+	flags := flag.NewFlagSet("schema-check", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: football schema-check <snapshot>...")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	reports := map[string]*endpointReport{}
+	skipped := map[string]int{}
+
+	for _, path := range flags.Args() {
+		snapshot, err := football.LoadSnapshot(path)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range snapshot.Keys() {
+			endpoint := football.Endpoint(key)
+
+			v, ok := football.NewResponse(key)
+			if !ok {
+				skipped[endpoint]++
+				continue
+			}
+
+			body, err := snapshot.Lookup(key)
+			if err != nil {
+				return err
+			}
+
+			drifts, err := football.CheckSchema(body, v)
+			if err != nil {
+				return fmt.Errorf("%s in %s: %w", key, path, err)
+			}
+
+			report, ok := reports[endpoint]
+			if !ok {
+				report = &endpointReport{drifts: map[football.Drift]int{}}
+				reports[endpoint] = report
+			}
+			report.responses++
+			for _, drift := range drifts {
+				count := drift.Count
+				drift.Count = 0
+				report.drifts[drift] += count
+			}
+		}
+	}
+
+	drifted := printSchemaReport(os.Stdout, reports, skipped)
+	if drifted > 0 {
+		return fmt.Errorf("schema drift in %d endpoints", drifted)
+	}
+	if len(reports) == 0 {
+		return errors.New("no responses to check")
+	}
+
+	return nil
+}
+
+// printSchemaReport writes the report and returns the number of endpoints
+// that drifted.
+func printSchemaReport(w io.Writer, reports map[string]*endpointReport, skipped map[string]int) int {
+	endpoints := make([]string, 0, len(reports))
+	for endpoint := range reports {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	drifted := 0
+	for _, endpoint := range endpoints {
+		report := reports[endpoint]
+		if len(report.drifts) == 0 {
+			fmt.Fprintf(w, "ok     %s (%d responses)\n", endpoint, report.responses)
+			continue
+		}
+
+		drifted++
+		fmt.Fprintf(w, "DRIFT  %s (%d responses)\n", endpoint, report.responses)
+
+		drifts := make([]football.Drift, 0, len(report.drifts))
+		for drift, count := range report.drifts {
+			drift.Count = count
+			drifts = append(drifts, drift)
+		}
+		sort.Slice(drifts, func(i, j int) bool {
+			if drifts[i].Field != drifts[j].Field {
+				return drifts[i].Field < drifts[j].Field
+			}
+			return drifts[i].Kind < drifts[j].Kind
+		})
+
+		for _, drift := range drifts {
+			fmt.Fprintf(w, "       %s, %d times\n", drift, drift.Count)
+		}
+	}
+
+	names := make([]string, 0, len(skipped))
+	for endpoint := range skipped {
+		names = append(names, endpoint)
+	}
+	sort.Strings(names)
+	for _, endpoint := range names {
+		fmt.Fprintf(w, "skip   %s (%d responses, not read by the SDK)\n", endpoint, skipped[endpoint])
+	}
+
+	return drifted
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// runSnapshot captures the areas, the competitions and, for each selected
+// competition, its teams, standings, matches and scorers.
+func runSnapshot(args []string) error {
+	flags := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	out := flags.String("out", "snapshot.tar.gz", "directory or .tar.gz file to write")
+	competitions := flags.String("competitions", "", "comma separated competition codes or IDs (default: all in -plan)")
+	plan := flags.String("plan", "TIER_ONE", "plan of the competitions captured by default")
+	season := flags.String("season", "", "season start year (default: current season)")
+	teams := flags.Bool("teams", false, "also capture every team, at one request per team")
+	interval := flags.Duration("interval", 6*time.Second, "minimum time between requests")
+	flags.Parse(args)
+
+	snapshot := football.NewSnapshot()
+	throttle := &throttle{interval: *interval, next: snapshot.Recorder(nil)}
+	client := football.NewClient(&http.Client{Transport: throttle})
+	ctx := context.Background()
+
+	if _, err := client.Areas.List(ctx); err != nil {
+		return err
+	}
+
+	list, err := client.Competitions.List(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	if *competitions != "" {
+		ids = strings.Split(*competitions, ",")
+	} else {
+		for _, competition := range list.Competitions {
+			if competition.Plan == *plan {
+				ids = append(ids, strconv.Itoa(competition.ID))
+			}
+		}
+	}
+
+	for _, id := range ids {
+		log.Printf("capturing competition %s", id)
+
+		if _, err := client.Competitions.Find(ctx, id); err != nil {
+			return err
+		}
+
+		var teamsFilters *football.CompetitionTeamsFiltersOptions
+		var standingsFilters *football.CompetitionStandingsFiltersOptions
+		var matchesFilters *football.CompetitionMatchesFiltersOptions
+		var scorersFilters *football.CompetitionScorersFiltersOptions
+		if *season != "" {
+			teamsFilters = &football.CompetitionTeamsFiltersOptions{Season: *season}
+			standingsFilters = &football.CompetitionStandingsFiltersOptions{Season: *season}
+			matchesFilters = &football.CompetitionMatchesFiltersOptions{Season: *season}
+			scorersFilters = &football.CompetitionScorersFiltersOptions{Season: *season}
+		}
+
+		competitionTeams, err := client.Competitions.Teams(ctx, id, teamsFilters)
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.Competitions.Standings(ctx, id, standingsFilters); err != nil {
+			return err
+		}
+
+		if _, err := client.Competitions.Matches(ctx, id, matchesFilters); err != nil {
+			return err
+		}
+
+		if _, err := client.Competitions.Scorers(ctx, id, scorersFilters); err != nil {
+			return err
+		}
+
+		if *teams {
+			for _, team := range competitionTeams.Teams {
+				if _, err := client.Teams.Find(ctx, strconv.Itoa(team.ID)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := snapshot.Save(*out); err != nil {
+		return err
+	}
+
+	log.Printf("wrote %d responses to %s", len(snapshot.Keys()), *out)
+	return nil
+}
+
+// throttle spaces out the requests sent through next by interval.
+type throttle struct {
+	interval time.Duration
+	next     http.RoundTripper
+	last     time.Time
+}
+
+func (t *throttle) RoundTrip(req *http.Request) (*http.Response, error) {
+	if wait := t.interval - time.Since(t.last); wait > 0 {
+		time.Sleep(wait)
+	}
+	t.last = time.Now()
+
+	return t.next.RoundTrip(req)
+}
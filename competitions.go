@@ -62,6 +62,7 @@ type CompetitionStandings struct {
 
 type CompetitionStandingsFiltersOptions struct {
 	StandingType StandingType `url:"standingType,omitempty"`
+	Season       string       `url:"season,omitempty"`
 }
 
 // CompetitionTeams represents a collection of Matches for
@@ -74,13 +75,13 @@ type CompetitionMatches struct {
 }
 
 type CompetitionMatchesFiltersOptions struct {
-	DateFrom string `json:"dateFrom,omitempty"`
-	DateTo   string `json:"dateTo,omitempty"`
-	Stage    string `json:"stage,omitempty"`
-	Status   Status `json:"status,omitempty"`
-	MatchDay string `json:"matchday,omitempty"`
-	Group    string `json:"group,omitempty"`
-	Season   string `json:"season,omitempty"`
+	DateFrom string `url:"dateFrom,omitempty"`
+	DateTo   string `url:"dateTo,omitempty"`
+	Stage    string `url:"stage,omitempty"`
+	Status   Status `url:"status,omitempty"`
+	MatchDay string `url:"matchday,omitempty"`
+	Group    string `url:"group,omitempty"`
+	Season   string `url:"season,omitempty"`
 }
 
 // CompetitionTeams represents a collection of Scorers for
@@ -94,7 +95,8 @@ type CompetitionScorers struct {
 }
 
 type CompetitionScorersFiltersOptions struct {
-	Limit string `json:"limit,omitempty"`
+	Limit  string `url:"limit,omitempty"`
+	Season string `url:"season,omitempty"`
 }
 
 // Find takes a Competition ID and returns the corresponding Competition
@@ -105,26 +107,44 @@ func (s *CompetitionService) Find(ctx context.Context, id string) (*Competition,
 		return nil, errors.New("Competition ID is required")
 	}
 
+	if err := s.client.checkPlan(ctx, id); err != nil {
+		return nil, err
+	}
+
 	competition := &Competition{}
 
-	_, err := s.client.Get(fmt.Sprintf("competitions/%s", id), nil, &competition)
+	_, err := s.client.GetContext(ctx, fmt.Sprintf("competitions/%s", id), nil, &competition)
 	if err != nil {
-		return nil, err
+		return nil, s.client.planError(id, err)
 	}
 
 	return competition, nil
 }
 
-// List returns a collection of all competitions.
+// List returns a collection of all competitions, restricted to the
+// plan of the client when it has one.
 // https://www.football-data.org/documentation/api
 func (s *CompetitionService) List(ctx context.Context, filters *CompetitionFiltersOptions) (*CompetitionList, error) {
 	competitions := &CompetitionList{}
 
-	_, err := s.client.Get("competitions", filters, &competitions)
+	_, err := s.client.GetContext(ctx, "competitions", filters, &competitions)
 	if err != nil {
 		return nil, err
 	}
 
+	s.client.plans.learn(competitions.Competitions)
+
+	if s.client.Plan != "" {
+		accessible := []Competition{}
+		for _, competition := range competitions.Competitions {
+			if s.client.Plan.Includes(Plan(competition.Plan)) {
+				accessible = append(accessible, competition)
+			}
+		}
+		competitions.Competitions = accessible
+		competitions.Count = len(accessible)
+	}
+
 	return competitions, nil
 }
 
@@ -137,10 +157,14 @@ func (s *CompetitionService) Teams(ctx context.Context, id string, filters *Comp
 	if len(id) == 0 {
 		return nil, errors.New("Competition ID is required")
 	}
-	_, err := s.client.Get(fmt.Sprintf("competitions/%s/teams", id), filters, &competitionTeams)
-	if err != nil {
+
+	if err := s.client.checkPlan(ctx, id); err != nil {
 		return nil, err
 	}
+	_, err := s.client.GetContext(ctx, fmt.Sprintf("competitions/%s/teams", id), filters, &competitionTeams)
+	if err != nil {
+		return nil, s.client.planError(id, err)
+	}
 
 	return competitionTeams, nil
 }
@@ -154,10 +178,14 @@ func (s *CompetitionService) Standings(ctx context.Context, id string, filters *
 	if len(id) == 0 {
 		return nil, errors.New("Competition ID is required")
 	}
-	_, err := s.client.Get(fmt.Sprintf("competitions/%s/standings", id), filters, &competitionStandings)
-	if err != nil {
+
+	if err := s.client.checkPlan(ctx, id); err != nil {
 		return nil, err
 	}
+	_, err := s.client.GetContext(ctx, fmt.Sprintf("competitions/%s/standings", id), filters, &competitionStandings)
+	if err != nil {
+		return nil, s.client.planError(id, err)
+	}
 
 	return competitionStandings, nil
 }
@@ -170,11 +198,15 @@ func (s *CompetitionService) Matches(ctx context.Context, id string, filters *Co
 		return nil, errors.New("Competition ID is required")
 	}
 
+	if err := s.client.checkPlan(ctx, id); err != nil {
+		return nil, err
+	}
+
 	competitionMatches := &CompetitionMatches{}
 
-	_, err := s.client.Get(fmt.Sprintf("competitions/%s/matches", id), filters, &competitionMatches)
+	_, err := s.client.GetContext(ctx, fmt.Sprintf("competitions/%s/matches", id), filters, &competitionMatches)
 	if err != nil {
-		return nil, err
+		return nil, s.client.planError(id, err)
 	}
 
 	return competitionMatches, nil
@@ -188,11 +220,15 @@ func (s *CompetitionService) Scorers(ctx context.Context, id string, filters *Co
 		return nil, errors.New("Competition ID is required")
 	}
 
+	if err := s.client.checkPlan(ctx, id); err != nil {
+		return nil, err
+	}
+
 	competitionScorers := &CompetitionScorers{}
 
-	_, err := s.client.Get(fmt.Sprintf("competitions/%s/scorers", id), filters, &competitionScorers)
+	_, err := s.client.GetContext(ctx, fmt.Sprintf("competitions/%s/scorers", id), filters, &competitionScorers)
 	if err != nil {
-		return nil, err
+		return nil, s.client.planError(id, err)
 	}
 
 	return competitionScorers, nil
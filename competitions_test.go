@@ -562,3 +562,19 @@ func TestCompetitionService_Scorers(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expected, list)
 }
+
+func TestCompetitionService_ScorersFilters(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/competitions/2013/scorers", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assert.Equal(t, "limit=20&season=2020", r.URL.RawQuery)
+		fmt.Fprint(w, `{"count": 0, "scorers": []}`)
+	})
+
+	client := NewClient(httpClient)
+	_, err := client.Competitions.Scorers(context.Background(), "2013", &CompetitionScorersFiltersOptions{Limit: "20", Season: "2020"})
+
+	assert.Nil(t, err)
+}
@@ -0,0 +1,359 @@
+// Package diff reports the field level changes between two versions of an
+// SDK resource, such as a Match before and after the API corrected a
+// scorer, as JSON Patch style operations.
+//
+// Paths are JSON Pointers built from the json names of the fields. Zero
+// values are compared like any other value, so a score going from 1 to 0
+// is a replace, not a remove. Slices listed in the keys of a Differ are
+// compared as collections keyed by some of their fields, so a goal added
+// before others does not shift every later goal; their elements appear in
+// paths by key instead of index, for example /goals/23:44/assist/id.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Op is the kind of an Operation.
+type Op string
+
+const (
+	Add     Op = "add"
+	Remove  Op = "remove"
+	Replace Op = "replace"
+)
+
+// Operation is a single change.
+type Operation struct {
+	Op   Op     `json:"op"`
+	Path string `json:"path"`
+	// Value is the new value, for add and replace.
+	Value interface{} `json:"value,omitempty"`
+	// Old is the previous value, for remove and replace.
+	Old interface{} `json:"old,omitempty"`
+}
+
+func (o Operation) String() string {
+	switch o.Op {
+	case Add:
+		return fmt.Sprintf("add %s: %v", o.Path, o.Value)
+	case Remove:
+		return fmt.Sprintf("remove %s: %v", o.Path, o.Old)
+	default:
+		return fmt.Sprintf("replace %s: %v -> %v", o.Path, o.Old, o.Value)
+	}
+}
+
+// DefaultKeys are the keys of the collections of the SDK types. Goals,
+// bookings and substitutions are keyed by minute and player, tables by
+// team and everything with an ID by its ID.
+var DefaultKeys = map[string][]string{
+	"/goals":              {"minute", "scorer.id"},
+	"/bookings":           {"minute", "player.id"},
+	"/substitutions":      {"minute", "playerOut.id"},
+	"/referees":           {"id"},
+	"/lineup":             {"id"},
+	"/bench":              {"id"},
+	"/squad":              {"id"},
+	"/table":              {"team.id"},
+	"/standings":          {"stage", "type", "group"},
+	"/seasons":            {"id"},
+	"/activeCompetitions": {"id"},
+	"/competitions":       {"id"},
+	"/matches":            {"id"},
+	"/teams":              {"id"},
+	"/scorers":            {"player.id"},
+	"/areas":              {"id"},
+	"/childAreas":         {"id"},
+}
+
+// Differ compares values.
+type Differ struct {
+	// Keys maps slice paths to the fields keying their elements, as
+	// dotted json names. A path matches the end of the path of a slice,
+	// with * matching any segment: "/table" matches every slice named
+	// table, "/standings/*/table" only those inside standings. The longest
+	// matching path wins. Other slices are compared by index.
+	Keys map[string][]string
+}
+
+// New returns a Differ with the DefaultKeys.
+func New() *Differ {
+	keys := map[string][]string{}
+	for path, fields := range DefaultKeys {
+		keys[path] = fields
+	}
+	return &Differ{Keys: keys}
+}
+
+// Diff compares old and current with the DefaultKeys.
+func Diff(old, current interface{}) []Operation {
+	return New().Diff(old, current)
+}
+
+// Diff returns the operations turning old into current.
+func (d *Differ) Diff(old, current interface{}) []Operation {
+	operations := []Operation{}
+	d.walk(&operations, nil, reflect.ValueOf(old), reflect.ValueOf(current))
+	return operations
+}
+
+// walk compares a and b at the path segments.
+func (d *Differ) walk(operations *[]Operation, path []string, a, b reflect.Value) {
+	a, b = indirect(a), indirect(b)
+
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return
+	case !a.IsValid():
+		*operations = append(*operations, Operation{Op: Add, Path: pointer(path), Value: b.Interface()})
+		return
+	case !b.IsValid():
+		*operations = append(*operations, Operation{Op: Remove, Path: pointer(path), Old: a.Interface()})
+		return
+	case a.Type() != b.Type():
+		d.replace(operations, path, a, b)
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Struct:
+		if opaque(a.Type()) {
+			if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+				d.replace(operations, path, a, b)
+			}
+			return
+		}
+		for i := 0; i < a.NumField(); i++ {
+			name, ok := fieldName(a.Type().Field(i))
+			if ok {
+				d.walk(operations, append(path, name), a.Field(i), b.Field(i))
+			}
+		}
+	case reflect.Map:
+		d.walkMap(operations, path, a, b)
+	case reflect.Slice, reflect.Array:
+		if fields, ok := d.keys(path); ok {
+			d.walkKeyed(operations, path, fields, a, b)
+		} else {
+			d.walkIndexed(operations, path, a, b)
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			d.replace(operations, path, a, b)
+		}
+	}
+}
+
+func (d *Differ) replace(operations *[]Operation, path []string, a, b reflect.Value) {
+	*operations = append(*operations, Operation{Op: Replace, Path: pointer(path), Value: b.Interface(), Old: a.Interface()})
+}
+
+func (d *Differ) walkMap(operations *[]Operation, path []string, a, b reflect.Value) {
+	names := map[string]bool{}
+	for _, key := range a.MapKeys() {
+		names[fmt.Sprint(key.Interface())] = true
+	}
+	for _, key := range b.MapKeys() {
+		names[fmt.Sprint(key.Interface())] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		key := reflect.ValueOf(name)
+		if !key.Type().AssignableTo(a.Type().Key()) {
+			continue
+		}
+		d.walk(operations, append(path, name), a.MapIndex(key), b.MapIndex(key))
+	}
+}
+
+func (d *Differ) walkIndexed(operations *[]Operation, path []string, a, b reflect.Value) {
+	common := a.Len()
+	if b.Len() < common {
+		common = b.Len()
+	}
+
+	for i := 0; i < common; i++ {
+		d.walk(operations, append(path, strconv.Itoa(i)), a.Index(i), b.Index(i))
+	}
+
+	// Removed from the end so every index stays valid.
+	for i := a.Len() - 1; i >= common; i-- {
+		*operations = append(*operations, Operation{Op: Remove, Path: pointer(append(path, strconv.Itoa(i))), Old: a.Index(i).Interface()})
+	}
+	for i := common; i < b.Len(); i++ {
+		*operations = append(*operations, Operation{Op: Add, Path: pointer(append(path, strconv.Itoa(i))), Value: b.Index(i).Interface()})
+	}
+}
+
+func (d *Differ) walkKeyed(operations *[]Operation, path []string, fields []string, a, b reflect.Value) {
+	oldKeys, oldIndex := elementKeys(a, fields)
+	newKeys, newIndex := elementKeys(b, fields)
+
+	for i, key := range oldKeys {
+		if _, ok := newIndex[key]; !ok {
+			*operations = append(*operations, Operation{Op: Remove, Path: pointer(append(path, key)), Old: a.Index(i).Interface()})
+		}
+	}
+
+	for i, key := range newKeys {
+		j, ok := oldIndex[key]
+		if !ok {
+			*operations = append(*operations, Operation{Op: Add, Path: pointer(append(path, key)), Value: b.Index(i).Interface()})
+			continue
+		}
+		d.walk(operations, append(path, key), a.Index(j), b.Index(i))
+	}
+}
+
+// keys returns the key fields of the slice at path.
+func (d *Differ) keys(path []string) ([]string, bool) {
+	var best []string
+	length := 0
+
+	for pattern, fields := range d.Keys {
+		segments := strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+		if len(segments) > len(path) || len(segments) <= length {
+			continue
+		}
+
+		matches := true
+		for i, segment := range segments {
+			if actual := path[len(path)-len(segments)+i]; segment != "*" && segment != actual {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			best, length = fields, len(segments)
+		}
+	}
+
+	return best, best != nil
+}
+
+// elementKeys returns the key of every element, in order, and the index of
+// every key. The second element with the same key gets a "#2" suffix, and
+// so on.
+func elementKeys(slice reflect.Value, fields []string) ([]string, map[string]int) {
+	keys := make([]string, slice.Len())
+	index := map[string]int{}
+
+	for i := 0; i < slice.Len(); i++ {
+		values := make([]string, len(fields))
+		for j, field := range fields {
+			values[j] = fieldValue(slice.Index(i), field)
+		}
+
+		key := strings.Join(values, ":")
+		for n := 2; ; n++ {
+			if _, ok := index[key]; !ok {
+				break
+			}
+			key = fmt.Sprintf("%s#%d", strings.Join(values, ":"), n)
+		}
+
+		keys[i] = key
+		index[key] = i
+	}
+
+	return keys, index
+}
+
+// fieldValue returns the value of the dotted json field of v as a string.
+func fieldValue(v reflect.Value, field string) string {
+	for _, name := range strings.Split(field, ".") {
+		v = indirect(v)
+
+		switch {
+		case !v.IsValid():
+			return ""
+		case v.Kind() == reflect.Struct:
+			next := reflect.Value{}
+			for i := 0; i < v.NumField(); i++ {
+				if fieldName, ok := fieldName(v.Type().Field(i)); ok && fieldName == name {
+					next = v.Field(i)
+					break
+				}
+			}
+			v = next
+		case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+			v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		default:
+			return ""
+		}
+	}
+
+	v = indirect(v)
+	if !v.IsValid() {
+		return ""
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// fieldName returns the json name of an exported field.
+func fieldName(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, true
+	}
+	return field.Name, true
+}
+
+var marshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// opaque reports whether a struct is compared as a whole: types with their
+// own JSON encoding, such as time.Time, or without exported fields.
+func opaque(t reflect.Type) bool {
+	if t.Implements(marshaler) || reflect.PtrTo(t).Implements(marshaler) {
+		return true
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// indirect follows pointers and interfaces, returning an invalid value for
+// nil. Nil slices and maps are kept, they compare as empty.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// pointer returns the JSON Pointer of the path segments.
+func pointer(path []string) string {
+	var b strings.Builder
+	for _, segment := range path {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(segment))
+	}
+	return b.String()
+}
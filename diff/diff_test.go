@@ -0,0 +1,163 @@
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff_Match(t *testing.T) {
+	old := football.Match{
+		ID:     1,
+		Status: "IN_PLAY",
+		Score:  &football.Score{FullTime: football.Time{HomeTeam: 1, AwayTeam: 0}},
+		Goals: []football.Goals{
+			{Minute: 23, Scorer: football.Player{ID: 44, Name: "Gabriel Barbosa"}},
+		},
+	}
+	current := football.Match{
+		ID:     1,
+		Status: "FINISHED",
+		Score:  &football.Score{Winner: "HOME_TEAM", FullTime: football.Time{HomeTeam: 2, AwayTeam: 0}},
+		Goals: []football.Goals{
+			{Minute: 12, Scorer: football.Player{ID: 45, Name: "Bruno Henrique"}},
+			{Minute: 23, Scorer: football.Player{ID: 44, Name: "Gabriel Barbosa"}, Assist: football.Player{ID: 46, Name: "Everton Ribeiro"}},
+		},
+	}
+
+	operations := Diff(old, current)
+
+	assert.Equal(t, []Operation{
+		{Op: Replace, Path: "/status", Old: "IN_PLAY", Value: "FINISHED"},
+		{Op: Replace, Path: "/score/winner", Old: "", Value: "HOME_TEAM"},
+		{Op: Replace, Path: "/score/fullTime/homeTeam", Old: 1, Value: 2},
+		{Op: Add, Path: "/goals/12:45", Value: current.Goals[0]},
+		{Op: Replace, Path: "/goals/23:44/assist/id", Old: int64(0), Value: int64(46)},
+		{Op: Replace, Path: "/goals/23:44/assist/name", Old: "", Value: "Everton Ribeiro"},
+	}, operations)
+}
+
+func TestDiff_ScorerCorrected(t *testing.T) {
+	old := football.Match{Goals: []football.Goals{{Minute: 23, Scorer: football.Player{ID: 44}}}}
+	current := football.Match{Goals: []football.Goals{{Minute: 23, Scorer: football.Player{ID: 45}}}}
+
+	operations := Diff(&old, &current)
+
+	assert.Equal(t, []Operation{
+		{Op: Remove, Path: "/goals/23:44", Old: old.Goals[0]},
+		{Op: Add, Path: "/goals/23:45", Value: current.Goals[0]},
+	}, operations)
+}
+
+func TestDiff_Standing(t *testing.T) {
+	old := football.Standing{Type: "TOTAL", Table: []football.Table{
+		{Position: 1, Team: football.Team{ID: 1783}, Points: 70},
+		{Position: 2, Team: football.Team{ID: 1766}, Points: 69},
+	}}
+	current := football.Standing{Type: "TOTAL", Table: []football.Table{
+		{Position: 1, Team: football.Team{ID: 1766}, Points: 72},
+		{Position: 2, Team: football.Team{ID: 1783}, Points: 70},
+	}}
+
+	operations := Diff(old, current)
+
+	assert.Equal(t, []Operation{
+		{Op: Replace, Path: "/table/1766/position", Old: 2, Value: 1},
+		{Op: Replace, Path: "/table/1766/points", Old: 69, Value: 72},
+		{Op: Replace, Path: "/table/1783/position", Old: 1, Value: 2},
+	}, operations)
+}
+
+func TestDiff_Team(t *testing.T) {
+	old := football.Team{ID: 1783, Squad: &[]football.Player{{ID: 10, Name: "Diego"}, {ID: 2, Name: "Pedro"}}}
+	current := football.Team{ID: 1783, Coach: &football.Coach{ID: 11}, Squad: &[]football.Player{{ID: 2, Name: "Pedro"}}}
+
+	operations := Diff(old, current)
+
+	assert.Equal(t, []Operation{
+		{Op: Add, Path: "/coach", Value: football.Coach{ID: 11}},
+		{Op: Remove, Path: "/squad/10", Old: football.Player{ID: 10, Name: "Diego"}},
+	}, operations)
+}
+
+func TestDiff_Competition(t *testing.T) {
+	old := football.Competition{ID: 2013, Seasons: []football.Season{{ID: 1, AvailableStages: []string{"A", "B"}}}}
+	current := football.Competition{ID: 2013, Seasons: []football.Season{{ID: 1, AvailableStages: []string{"A"}}}}
+
+	operations := Diff(old, current)
+
+	assert.Equal(t, []Operation{
+		{Op: Remove, Path: "/seasons/1/availableStages/1", Old: "B"},
+	}, operations)
+}
+
+func TestDiff_MapsAndInterfaces(t *testing.T) {
+	old := football.CompetitionMatches{Filters: map[string]interface{}{"season": "2021", "a/b": 1}}
+	current := football.CompetitionMatches{Filters: map[string]interface{}{"season": 2021.0, "stage": "FINAL"}}
+
+	operations := Diff(old, current)
+
+	assert.Equal(t, []Operation{
+		{Op: Remove, Path: "/filters/a~1b", Old: 1},
+		{Op: Replace, Path: "/filters/season", Old: "2021", Value: 2021.0},
+		{Op: Add, Path: "/filters/stage", Value: "FINAL"},
+	}, operations)
+}
+
+func TestDiffer_Keys(t *testing.T) {
+	type row struct {
+		Name    string    `json:"name"`
+		Updated time.Time `json:"updated"`
+	}
+	type document struct {
+		Rows []row `json:"rows"`
+	}
+
+	old := document{Rows: []row{{Name: "a"}, {Name: "b"}}}
+	current := document{Rows: []row{{Name: "b", Updated: time.Unix(1, 0).UTC()}}}
+
+	operations := Diff(old, current)
+	assert.Equal(t, "/rows/1", operations[len(operations)-1].Path)
+
+	differ := &Differ{Keys: map[string][]string{"/rows": {"name"}}}
+	operations = differ.Diff(old, current)
+
+	assert.Equal(t, []Operation{
+		{Op: Remove, Path: "/rows/a", Old: row{Name: "a"}},
+		{Op: Replace, Path: "/rows/b/updated", Old: time.Time{}, Value: time.Unix(1, 0).UTC()},
+	}, operations)
+}
+
+func TestDiff_DuplicateKeys(t *testing.T) {
+	old := football.Match{Bookings: []football.Bookings{
+		{Minute: 40, Player: football.Player{ID: 7}, Card: "YELLOW_CARD"},
+	}}
+	current := football.Match{Bookings: []football.Bookings{
+		{Minute: 40, Player: football.Player{ID: 7}, Card: "YELLOW_CARD"},
+		{Minute: 40, Player: football.Player{ID: 7}, Card: "RED_CARD"},
+	}}
+
+	operations := Diff(old, current)
+
+	assert.Equal(t, []Operation{{Op: Add, Path: "/bookings/40:7#2", Value: current.Bookings[1]}}, operations)
+}
+
+func TestDiff_Equal(t *testing.T) {
+	match := football.Match{ID: 1, Score: &football.Score{}}
+
+	assert.Empty(t, Diff(match, match))
+	assert.Empty(t, Diff(nil, nil))
+}
+
+func TestOperation(t *testing.T) {
+	operation := Operation{Op: Replace, Path: "/score/fullTime/homeTeam", Old: 1, Value: 2}
+
+	assert.Equal(t, "replace /score/fullTime/homeTeam: 1 -> 2", operation.String())
+
+	body, err := json.Marshal(operation)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"op": "replace", "path": "/score/fullTime/homeTeam", "value": 2, "old": 1}`, string(body))
+}
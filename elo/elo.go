@@ -0,0 +1,222 @@
+// Package elo maintains Elo ratings of teams from their match history and
+// predicts the outcome of upcoming matches.
+package elo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/matheustex/football-data-sdk/simulation"
+)
+
+// ErrOutOfOrder is returned when a match is older than the last match
+// applied to the ratings.
+var ErrOutOfOrder = errors.New("Matches must be applied in chronological order")
+
+// Config controls how ratings are updated.
+type Config struct {
+	Initial        float64                      // Rating of new teams, defaults to 1500.
+	K              float64                      // Weight of each match, defaults to 20.
+	KFactor        func(football.Match) float64 // Overrides K per match, e.g. by competition or stage.
+	HomeAdvantage  float64                      // Rating points added to the home team.
+	GoalDifference bool                         // Scale the update by the margin of victory.
+	DrawRate       float64                      // Draw probability between equal teams, defaults to 0.26.
+}
+
+// Point is the rating of a team after a match.
+type Point struct {
+	MatchID int       `json:"matchId"`
+	Date    time.Time `json:"date"`
+	Rating  float64   `json:"rating"`
+	Change  float64   `json:"change"`
+}
+
+// Prediction holds the outcome probabilities of a match.
+type Prediction struct {
+	MatchID int     `json:"matchId"`
+	Home    float64 `json:"home"`
+	Draw    float64 `json:"draw"`
+	Away    float64 `json:"away"`
+}
+
+// Engine holds the ratings of every team seen so far.
+type Engine struct {
+	cfg      Config
+	ratings  map[int]float64
+	timeline map[int][]Point
+	last     time.Time
+}
+
+// New returns an Engine with no ratings.
+func New(cfg Config) *Engine {
+	if cfg.Initial <= 0 {
+		cfg.Initial = 1500
+	}
+	if cfg.K <= 0 {
+		cfg.K = 20
+	}
+
+	return &Engine{cfg: cfg, ratings: map[int]float64{}, timeline: map[int][]Point{}}
+}
+
+// Update applies the result of match to the ratings. Matches that are not
+// finished are ignored.
+func (e *Engine) Update(match football.Match) error {
+	if match.Status != string(football.StatusFinished) || match.Score == nil || match.HomeTeam == nil || match.AwayTeam == nil {
+		return nil
+	}
+
+	date, err := time.Parse(time.RFC3339, match.UtcDate)
+	if err != nil {
+		return err
+	}
+	if date.Before(e.last) {
+		return ErrOutOfOrder
+	}
+	e.last = date
+
+	home, away := match.HomeTeam.ID, match.AwayTeam.ID
+	homeGoals := match.Score.FullTime.HomeTeam - match.Score.Penalties.HomeTeam
+	awayGoals := match.Score.FullTime.AwayTeam - match.Score.Penalties.AwayTeam
+
+	actual := 0.5
+	switch {
+	case homeGoals > awayGoals:
+		actual = 1
+	case homeGoals < awayGoals:
+		actual = 0
+	}
+
+	diff := e.Rating(home) + e.cfg.HomeAdvantage - e.Rating(away)
+	expected := 1 / (1 + math.Pow(10, -diff/400))
+
+	k := e.cfg.K
+	if e.cfg.KFactor != nil {
+		k = e.cfg.KFactor(match)
+	}
+	if e.cfg.GoalDifference {
+		k *= margin(homeGoals - awayGoals)
+	}
+
+	change := k * (actual - expected)
+	e.apply(home, match.ID, date, change)
+	e.apply(away, match.ID, date, -change)
+
+	return nil
+}
+
+// UpdateAll applies matches in order.
+func (e *Engine) UpdateAll(matches []football.Match) error {
+	for _, match := range matches {
+		if err := e.Update(match); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Feed applies the matches received on the channel until it is closed or
+// ctx is done.
+func (e *Engine) Feed(ctx context.Context, matches <-chan football.Match) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case match, ok := <-matches:
+			if !ok {
+				return nil
+			}
+			if err := e.Update(match); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Rating returns the current rating of a team.
+func (e *Engine) Rating(team int) float64 {
+	if rating, ok := e.ratings[team]; ok {
+		return rating
+	}
+	return e.cfg.Initial
+}
+
+// Ratings returns a copy of the current ratings by team ID.
+func (e *Engine) Ratings() map[int]float64 {
+	ratings := make(map[int]float64, len(e.ratings))
+	for team, rating := range e.ratings {
+		ratings[team] = rating
+	}
+	return ratings
+}
+
+// Timeline returns the rating of a team after each of its matches.
+func (e *Engine) Timeline(team int) []Point {
+	points := make([]Point, len(e.timeline[team]))
+	copy(points, e.timeline[team])
+	return points
+}
+
+// Model returns a simulation model using the current ratings.
+func (e *Engine) Model() *simulation.Elo {
+	return &simulation.Elo{
+		Ratings:       e.Ratings(),
+		Default:       e.cfg.Initial,
+		HomeAdvantage: e.cfg.HomeAdvantage,
+		DrawRate:      e.cfg.DrawRate,
+	}
+}
+
+// Predict returns the outcome probabilities of match.
+func (e *Engine) Predict(match football.Match) (*Prediction, error) {
+	if match.HomeTeam == nil || match.AwayTeam == nil {
+		return nil, errors.New("Match teams are required")
+	}
+
+	home, draw, away := e.Model().Probabilities(match.HomeTeam.ID, match.AwayTeam.ID)
+
+	return &Prediction{MatchID: match.ID, Home: home, Draw: draw, Away: away}, nil
+}
+
+// PredictScheduled returns the predictions of the scheduled matches,
+// whether or not their kick-off time is confirmed.
+func (e *Engine) PredictScheduled(matches []football.Match) []Prediction {
+	predictions := []Prediction{}
+	for _, match := range matches {
+		switch football.Status(match.Status) {
+		case football.StatusScheduled, football.StatusTimed:
+		default:
+			continue
+		}
+		if prediction, err := e.Predict(match); err == nil {
+			predictions = append(predictions, *prediction)
+		}
+	}
+	return predictions
+}
+
+func (e *Engine) apply(team, matchID int, date time.Time, change float64) {
+	rating := e.Rating(team) + change
+	e.ratings[team] = rating
+	e.timeline[team] = append(e.timeline[team], Point{MatchID: matchID, Date: date, Rating: rating, Change: change})
+}
+
+// margin returns the multiplier for the margin of victory used by the
+// World Football Elo Ratings.
+func margin(diff int) float64 {
+	if diff < 0 {
+		diff = -diff
+	}
+
+	switch diff {
+	case 0, 1:
+		return 1
+	case 2:
+		return 1.5
+	default:
+		return (11 + float64(diff)) / 8
+	}
+}
@@ -0,0 +1,109 @@
+package elo
+
+import (
+	"context"
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func match(id int, date string, home, homeGoals, awayGoals, away int) football.Match {
+	return football.Match{
+		ID:       id,
+		UtcDate:  date,
+		Status:   string(football.StatusFinished),
+		HomeTeam: &football.Team{ID: home},
+		AwayTeam: &football.Team{ID: away},
+		Score:    &football.Score{FullTime: football.Time{HomeTeam: homeGoals, AwayTeam: awayGoals}},
+	}
+}
+
+func TestEngine_Update(t *testing.T) {
+	e := New(Config{})
+
+	err := e.UpdateAll([]football.Match{
+		match(1, "2021-08-14T14:00:00Z", 57, 2, 0, 61),
+		match(2, "2021-08-21T14:00:00Z", 61, 1, 1, 57),
+	})
+
+	assert.Nil(t, err)
+	assert.InDelta(t, 1510, e.Timeline(57)[0].Rating, 1e-9)
+	assert.True(t, e.Rating(57) > 1500)
+	assert.InDelta(t, 3000, e.Rating(57)+e.Rating(61), 1e-9)
+	assert.Equal(t, 1500.0, e.Rating(99))
+
+	timeline := e.Timeline(61)
+	assert.Len(t, timeline, 2)
+	assert.Equal(t, 1, timeline[0].MatchID)
+	assert.InDelta(t, -10, timeline[0].Change, 1e-9)
+	assert.InDelta(t, 1490, timeline[0].Rating, 1e-9)
+}
+
+func TestEngine_Config(t *testing.T) {
+	e := New(Config{
+		K:              40,
+		HomeAdvantage:  100,
+		GoalDifference: true,
+		KFactor: func(m football.Match) float64 {
+			if m.Stage == "FINAL" {
+				return 60
+			}
+			return 40
+		},
+	})
+
+	m := match(1, "2021-08-14T14:00:00Z", 57, 3, 0, 61)
+	m.Stage = "FINAL"
+	e.Update(m)
+
+	// Expected home score with a 100 point advantage is 0.64.
+	assert.InDelta(t, 60*1.75*(1-0.6400649998), e.Timeline(57)[0].Change, 1e-6)
+}
+
+func TestEngine_OutOfOrder(t *testing.T) {
+	e := New(Config{})
+
+	assert.Nil(t, e.Update(match(2, "2021-08-21T14:00:00Z", 57, 2, 0, 61)))
+	assert.Equal(t, ErrOutOfOrder, e.Update(match(1, "2021-08-14T14:00:00Z", 57, 2, 0, 61)))
+}
+
+func TestEngine_Feed(t *testing.T) {
+	e := New(Config{})
+
+	matches := make(chan football.Match, 2)
+	matches <- match(1, "2021-08-14T14:00:00Z", 57, 2, 0, 61)
+	matches <- match(2, "2021-08-21T14:00:00Z", 61, 1, 1, 57)
+	close(matches)
+
+	assert.Nil(t, e.Feed(context.Background(), matches))
+	assert.Len(t, e.Ratings(), 2)
+}
+
+func TestEngine_PredictScheduled(t *testing.T) {
+	e := New(Config{})
+	e.Update(match(1, "2021-08-14T14:00:00Z", 57, 2, 0, 61))
+
+	scheduled := football.Match{
+		ID:       3,
+		Status:   string(football.StatusScheduled),
+		HomeTeam: &football.Team{ID: 57},
+		AwayTeam: &football.Team{ID: 61},
+	}
+
+	timed := scheduled
+	timed.ID, timed.Status = 4, string(football.StatusTimed)
+
+	predictions := e.PredictScheduled([]football.Match{
+		match(2, "2021-08-21T14:00:00Z", 61, 1, 1, 57),
+		scheduled,
+		timed,
+	})
+
+	assert.Len(t, predictions, 2)
+	assert.Equal(t, 3, predictions[0].MatchID)
+	assert.Equal(t, 4, predictions[1].MatchID)
+	assert.Equal(t, predictions[0].Home, predictions[1].Home)
+	assert.True(t, predictions[0].Home > predictions[0].Away)
+	assert.InDelta(t, 1.0, predictions[0].Home+predictions[0].Draw+predictions[0].Away, 1e-9)
+}
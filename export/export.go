@@ -0,0 +1,108 @@
+// Package export flattens matches, standings, scorers, teams and players
+// into stable column schemas and writes them as CSV or Parquet, for loading
+// into a warehouse.
+//
+// Nested fields become dotted columns, such as homeTeam.name or
+// score.fullTime.homeTeam; every column is documented in its schema.
+//
+//	w := export.NewCSVWriter(file, export.MatchSchema)
+//	err := export.WriteMatches(w, matches)
+package export
+
+import (
+	"io"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// MatchIterator yields matches one at a time, so large exports never hold
+// all of them in memory. Next returns io.EOF after the last match.
+type MatchIterator interface {
+	Next() (football.Match, error)
+}
+
+// MatchIteratorFunc adapts a function to a MatchIterator.
+type MatchIteratorFunc func() (football.Match, error)
+
+// Next calls f.
+func (f MatchIteratorFunc) Next() (football.Match, error) {
+	return f()
+}
+
+// StreamMatches writes the matches of it to w, which must have been
+// created with MatchSchema, then closes w. It returns the number of
+// matches written.
+func StreamMatches(w Writer, it MatchIterator) (int, error) {
+	count := 0
+	for {
+		match, err := it.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return count, err
+		}
+
+		if err := w.Write(MatchSchema.Row(match)); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, w.Close()
+}
+
+// WriteMatches writes matches to w, which must have been created with
+// MatchSchema, then closes w.
+func WriteMatches(w Writer, matches []football.Match) error {
+	for _, match := range matches {
+		if err := w.Write(MatchSchema.Row(match)); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// WriteTables writes standings rows to w, which must have been created
+// with TableSchema, then closes w.
+func WriteTables(w Writer, tables []football.Table) error {
+	for _, table := range tables {
+		if err := w.Write(TableSchema.Row(table)); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// WriteScorers writes scorers to w, which must have been created with
+// ScorerSchema, then closes w.
+func WriteScorers(w Writer, scorers []football.Scorer) error {
+	for _, scorer := range scorers {
+		if err := w.Write(ScorerSchema.Row(scorer)); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// WriteTeams writes teams to w, which must have been created with
+// TeamSchema, then closes w.
+func WriteTeams(w Writer, teams []football.Team) error {
+	for _, team := range teams {
+		if err := w.Write(TeamSchema.Row(team)); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// WritePlayers writes players to w, which must have been created with
+// PlayerSchema, then closes w.
+func WritePlayers(w Writer, players []football.Player) error {
+	for _, player := range players {
+		if err := w.Write(PlayerSchema.Row(player)); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
@@ -0,0 +1,65 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamMatches(t *testing.T) {
+	var out bytes.Buffer
+
+	next := 0
+	it := MatchIteratorFunc(func() (football.Match, error) {
+		if next == 3 {
+			return football.Match{}, io.EOF
+		}
+		next++
+		return football.Match{ID: next, Status: string(football.StatusScheduled)}, nil
+	})
+
+	count, err := StreamMatches(NewCSVWriter(&out, MatchSchema), it)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, 4, len(lines))
+	assert.True(t, strings.HasPrefix(lines[3], "3,,SCHEDULED,"))
+}
+
+func TestStreamMatches_StopsOnError(t *testing.T) {
+	var out bytes.Buffer
+
+	failure := errors.New("page 2 failed")
+	calls := 0
+	it := MatchIteratorFunc(func() (football.Match, error) {
+		calls++
+		if calls == 2 {
+			return football.Match{}, failure
+		}
+		return football.Match{ID: calls}, nil
+	})
+
+	count, err := StreamMatches(NewCSVWriter(&out, MatchSchema), it)
+
+	assert.Equal(t, failure, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestWritePlayers(t *testing.T) {
+	var out bytes.Buffer
+
+	err := WritePlayers(NewCSVWriter(&out, PlayerSchema), []football.Player{
+		{ID: 3, Name: "Fred", Position: "Attacker", ShirtNumber: 9, Role: "PLAYER"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "id,name,firstName,lastName,dateOfBirth,countryOfBirth,nationality,position,shirtNumber,role,lastUpdated\n"+
+		"3,Fred,,,,,,Attacker,9,PLAYER,\n", out.String())
+}
@@ -0,0 +1,281 @@
+package export
+
+import (
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// Kind is the type of the values of a column.
+type Kind string
+
+const (
+	// String columns hold UTF-8 text.
+	String Kind = "string"
+	// Int columns hold 64-bit integers.
+	Int Kind = "int"
+)
+
+// Column describes an exported column. Every column is nullable; empty
+// values are exported as null.
+type Column struct {
+	Name        string
+	Kind        Kind
+	Description string
+}
+
+// Schema is the stable list of columns an entity is flattened into.
+// Columns are only ever appended, never renamed, removed or reordered.
+type Schema struct {
+	Name    string
+	Columns []Column
+	values  []func(v interface{}) interface{}
+}
+
+// Row flattens v, which must be the entity of the schema, into the column
+// values.
+func (s *Schema) Row(v interface{}) []interface{} {
+	row := make([]interface{}, len(s.values))
+	for i, value := range s.values {
+		row[i] = value(v)
+	}
+	return row
+}
+
+// Names returns the column names.
+func (s *Schema) Names() []string {
+	names := make([]string, len(s.Columns))
+	for i, column := range s.Columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+type field struct {
+	Column
+	value func(v interface{}) interface{}
+}
+
+func newSchema(name string, fields []field) *Schema {
+	s := &Schema{Name: name}
+	for _, f := range fields {
+		s.Columns = append(s.Columns, f.Column)
+		s.values = append(s.values, f.value)
+	}
+	return s
+}
+
+// MatchSchema flattens a football.Match. Score columns are null until the
+// match kicks off, and the extra time and penalties columns are null unless
+// the match went that far.
+var MatchSchema = newSchema("match", []field{
+	{Column{"id", Int, "Match ID."}, match(func(m football.Match) interface{} { return id(m.ID) })},
+	{Column{"utcDate", String, "Kick-off, RFC 3339 in UTC."}, match(func(m football.Match) interface{} { return text(m.UtcDate) })},
+	{Column{"status", String, "SCHEDULED, LIVE, IN_PLAY, PAUSED, FINISHED, POSTPONED, SUSPENDED or CANCELED."}, match(func(m football.Match) interface{} { return text(m.Status) })},
+	{Column{"matchday", Int, "Matchday of league matches."}, match(func(m football.Match) interface{} { return id(m.Matchday) })},
+	{Column{"stage", String, "Stage, such as REGULAR_SEASON or FINAL."}, match(func(m football.Match) interface{} { return text(m.Stage) })},
+	{Column{"group", String, "Group of group stage matches."}, match(func(m football.Match) interface{} { return text(m.Group) })},
+	{Column{"venue", String, "Stadium."}, match(func(m football.Match) interface{} { return text(m.Venue) })},
+	{Column{"attendance", Int, "Spectators."}, match(func(m football.Match) interface{} { return id(m.Attendance) })},
+	{Column{"lastUpdated", String, "Last change, RFC 3339 in UTC."}, match(func(m football.Match) interface{} { return text(m.LastUpdated) })},
+	{Column{"competition.id", Int, "Competition ID."}, match(func(m football.Match) interface{} {
+		if m.Competition == nil {
+			return nil
+		}
+		return id(m.Competition.ID)
+	})},
+	{Column{"competition.name", String, "Competition name."}, match(func(m football.Match) interface{} {
+		if m.Competition == nil {
+			return nil
+		}
+		return text(m.Competition.Name)
+	})},
+	{Column{"season.id", Int, "Season ID."}, match(func(m football.Match) interface{} {
+		if m.Season == nil {
+			return nil
+		}
+		return id(m.Season.ID)
+	})},
+	{Column{"season.startDate", String, "First day of the season, YYYY-MM-DD."}, match(func(m football.Match) interface{} {
+		if m.Season == nil {
+			return nil
+		}
+		return text(m.Season.StartDate)
+	})},
+	{Column{"homeTeam.id", Int, "Home team ID."}, match(func(m football.Match) interface{} { return teamID(m.HomeTeam) })},
+	{Column{"homeTeam.name", String, "Home team name."}, match(func(m football.Match) interface{} { return teamName(m.HomeTeam) })},
+	{Column{"awayTeam.id", Int, "Away team ID."}, match(func(m football.Match) interface{} { return teamID(m.AwayTeam) })},
+	{Column{"awayTeam.name", String, "Away team name."}, match(func(m football.Match) interface{} { return teamName(m.AwayTeam) })},
+	{Column{"score.winner", String, "HOME_TEAM, AWAY_TEAM or DRAW."}, match(func(m football.Match) interface{} {
+		if m.Score == nil {
+			return nil
+		}
+		return text(m.Score.Winner)
+	})},
+	{Column{"score.duration", String, "REGULAR, EXTRA_TIME or PENALTY_SHOOTOUT."}, match(func(m football.Match) interface{} {
+		if m.Score == nil {
+			return nil
+		}
+		return text(m.Score.Duration)
+	})},
+	{Column{"score.fullTime.homeTeam", Int, "Home goals at full time."}, score(func(s football.Score) football.Time { return s.FullTime }, "", true)},
+	{Column{"score.fullTime.awayTeam", Int, "Away goals at full time."}, score(func(s football.Score) football.Time { return s.FullTime }, "", false)},
+	{Column{"score.halfTime.homeTeam", Int, "Home goals at half time."}, score(func(s football.Score) football.Time { return s.HalfTime }, "", true)},
+	{Column{"score.halfTime.awayTeam", Int, "Away goals at half time."}, score(func(s football.Score) football.Time { return s.HalfTime }, "", false)},
+	{Column{"score.extraTime.homeTeam", Int, "Home goals in extra time."}, score(func(s football.Score) football.Time { return s.ExtraTime }, "EXTRA_TIME", true)},
+	{Column{"score.extraTime.awayTeam", Int, "Away goals in extra time."}, score(func(s football.Score) football.Time { return s.ExtraTime }, "EXTRA_TIME", false)},
+	{Column{"score.penalties.homeTeam", Int, "Home penalties scored in the shoot-out."}, score(func(s football.Score) football.Time { return s.Penalties }, "PENALTY_SHOOTOUT", true)},
+	{Column{"score.penalties.awayTeam", Int, "Away penalties scored in the shoot-out."}, score(func(s football.Score) football.Time { return s.Penalties }, "PENALTY_SHOOTOUT", false)},
+})
+
+// TableSchema flattens a football.Table standings row.
+var TableSchema = newSchema("table", []field{
+	{Column{"position", Int, "Position in the table."}, table(func(t football.Table) interface{} { return int64(t.Position) })},
+	{Column{"team.id", Int, "Team ID."}, table(func(t football.Table) interface{} { return id(t.Team.ID) })},
+	{Column{"team.name", String, "Team name."}, table(func(t football.Table) interface{} { return text(t.Team.Name) })},
+	{Column{"playedGames", Int, "Matches played."}, table(func(t football.Table) interface{} { return int64(t.PlayedGames) })},
+	{Column{"won", Int, "Matches won."}, table(func(t football.Table) interface{} { return int64(t.Won) })},
+	{Column{"draw", Int, "Matches drawn."}, table(func(t football.Table) interface{} { return int64(t.Draw) })},
+	{Column{"lost", Int, "Matches lost."}, table(func(t football.Table) interface{} { return int64(t.Lost) })},
+	{Column{"points", Int, "Points."}, table(func(t football.Table) interface{} { return int64(t.Points) })},
+	{Column{"goalsFor", Int, "Goals scored."}, table(func(t football.Table) interface{} { return int64(t.GoalsFor) })},
+	{Column{"goalsAgainst", Int, "Goals conceded."}, table(func(t football.Table) interface{} { return int64(t.GoalsAgainst) })},
+	{Column{"goalDifference", Int, "Goals scored minus conceded."}, table(func(t football.Table) interface{} { return int64(t.GoalDifference) })},
+})
+
+// ScorerSchema flattens a football.Scorer.
+var ScorerSchema = newSchema("scorer", []field{
+	{Column{"player.id", Int, "Player ID."}, scorer(func(s football.Scorer) interface{} { return id64(s.Player.ID) })},
+	{Column{"player.name", String, "Player name."}, scorer(func(s football.Scorer) interface{} { return text(s.Player.Name) })},
+	{Column{"player.nationality", String, "Player nationality."}, scorer(func(s football.Scorer) interface{} { return text(s.Player.Nationality) })},
+	{Column{"player.position", String, "Goalkeeper, Defender, Midfielder or Attacker."}, scorer(func(s football.Scorer) interface{} { return text(s.Player.Position) })},
+	{Column{"team.id", Int, "Team ID."}, scorer(func(s football.Scorer) interface{} { return id(s.Team.ID) })},
+	{Column{"team.name", String, "Team name."}, scorer(func(s football.Scorer) interface{} { return text(s.Team.Name) })},
+	{Column{"numberOfGoals", Int, "Goals scored."}, scorer(func(s football.Scorer) interface{} { return int64(s.NumberOfGoals) })},
+})
+
+// TeamSchema flattens a football.Team, without its squad.
+var TeamSchema = newSchema("team", []field{
+	{Column{"id", Int, "Team ID."}, team(func(t football.Team) interface{} { return id(t.ID) })},
+	{Column{"name", String, "Full name."}, team(func(t football.Team) interface{} { return text(t.Name) })},
+	{Column{"shortName", String, "Short name."}, team(func(t football.Team) interface{} { return text(t.ShortName) })},
+	{Column{"tla", String, "Three letter abbreviation."}, team(func(t football.Team) interface{} { return text(t.Tla) })},
+	{Column{"area.id", Int, "Area ID."}, team(func(t football.Team) interface{} {
+		if t.Area == nil {
+			return nil
+		}
+		return id(t.Area.ID)
+	})},
+	{Column{"area.name", String, "Area name."}, team(func(t football.Team) interface{} {
+		if t.Area == nil {
+			return nil
+		}
+		return text(t.Area.Name)
+	})},
+	{Column{"founded", Int, "Year founded."}, team(func(t football.Team) interface{} { return id(t.Founded) })},
+	{Column{"venue", String, "Home stadium."}, team(func(t football.Team) interface{} { return text(t.Venue) })},
+	{Column{"clubColors", String, "Club colors."}, team(func(t football.Team) interface{} { return text(t.ClubColors) })},
+	{Column{"website", String, "Website URL."}, team(func(t football.Team) interface{} { return text(t.Website) })},
+	{Column{"crestUrl", String, "Crest image URL."}, team(func(t football.Team) interface{} { return text(t.CrestURL) })},
+	{Column{"lastUpdated", String, "Last change, RFC 3339 in UTC."}, team(func(t football.Team) interface{} { return text(t.LastUpdated) })},
+})
+
+// PlayerSchema flattens a football.Player.
+var PlayerSchema = newSchema("player", []field{
+	{Column{"id", Int, "Player ID."}, player(func(p football.Player) interface{} { return id64(p.ID) })},
+	{Column{"name", String, "Full name."}, player(func(p football.Player) interface{} { return text(p.Name) })},
+	{Column{"firstName", String, "First name."}, player(func(p football.Player) interface{} { return text(p.FirstName) })},
+	{Column{"lastName", String, "Last name."}, player(func(p football.Player) interface{} { return text(p.LastName) })},
+	{Column{"dateOfBirth", String, "Date of birth, YYYY-MM-DD."}, player(func(p football.Player) interface{} { return text(p.DateOfBirth) })},
+	{Column{"countryOfBirth", String, "Country of birth."}, player(func(p football.Player) interface{} { return text(p.CountryOfBirth) })},
+	{Column{"nationality", String, "Nationality."}, player(func(p football.Player) interface{} { return text(p.Nationality) })},
+	{Column{"position", String, "Goalkeeper, Defender, Midfielder or Attacker."}, player(func(p football.Player) interface{} { return text(p.Position) })},
+	{Column{"shirtNumber", Int, "Shirt number."}, player(func(p football.Player) interface{} { return id(p.ShirtNumber) })},
+	{Column{"role", String, "Role in a squad, such as PLAYER or COACH."}, player(func(p football.Player) interface{} { return text(p.Role) })},
+	{Column{"lastUpdated", String, "Last change, RFC 3339 in UTC."}, player(func(p football.Player) interface{} { return text(p.LastUpdated) })},
+})
+
+func match(f func(m football.Match) interface{}) func(v interface{}) interface{} {
+	return func(v interface{}) interface{} { return f(v.(football.Match)) }
+}
+
+func table(f func(t football.Table) interface{}) func(v interface{}) interface{} {
+	return func(v interface{}) interface{} { return f(v.(football.Table)) }
+}
+
+func scorer(f func(s football.Scorer) interface{}) func(v interface{}) interface{} {
+	return func(v interface{}) interface{} { return f(v.(football.Scorer)) }
+}
+
+func team(f func(t football.Team) interface{}) func(v interface{}) interface{} {
+	return func(v interface{}) interface{} { return f(v.(football.Team)) }
+}
+
+func player(f func(p football.Player) interface{}) func(v interface{}) interface{} {
+	return func(v interface{}) interface{} { return f(v.(football.Player)) }
+}
+
+// score returns the home or away goals of a score period, or nil before
+// kick-off and, when duration is set, for matches not lasting that long.
+func score(period func(s football.Score) football.Time, duration string, home bool) func(v interface{}) interface{} {
+	return match(func(m football.Match) interface{} {
+		if m.Score == nil || !started(m) {
+			return nil
+		}
+
+		if duration == "EXTRA_TIME" && m.Score.Duration != "EXTRA_TIME" && m.Score.Duration != "PENALTY_SHOOTOUT" {
+			return nil
+		}
+		if duration == "PENALTY_SHOOTOUT" && m.Score.Duration != "PENALTY_SHOOTOUT" {
+			return nil
+		}
+
+		t := period(*m.Score)
+		if home {
+			return int64(t.HomeTeam)
+		}
+		return int64(t.AwayTeam)
+	})
+}
+
+func started(m football.Match) bool {
+	switch football.Status(m.Status) {
+	case football.StatusScheduled, football.StatusPostPoned, football.StatusCanceled, football.StatusTimed, "":
+		return false
+	}
+	return true
+}
+
+func teamID(t *football.Team) interface{} {
+	if t == nil {
+		return nil
+	}
+	return id(t.ID)
+}
+
+func teamName(t *football.Team) interface{} {
+	if t == nil {
+		return nil
+	}
+	return text(t.Name)
+}
+
+// id returns nil for zero, which the API uses for missing numbers.
+func id(v int) interface{} {
+	if v == 0 {
+		return nil
+	}
+	return int64(v)
+}
+
+func id64(v int64) interface{} {
+	if v == 0 {
+		return nil
+	}
+	return v
+}
+
+func text(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,108 @@
+package export
+
+import (
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func testMatch() football.Match {
+	return football.Match{
+		ID:          204950,
+		Competition: &football.Competition{ID: 2013, Name: "Série A"},
+		Season:      &football.Season{ID: 15, StartDate: "2018-04-14"},
+		UtcDate:     "2018-08-13T23:00:00Z",
+		Status:      string(football.StatusFinished),
+		Matchday:    18,
+		Stage:       "REGULAR_SEASON",
+		Venue:       "Maracanã",
+		LastUpdated: "2018-08-19T20:01:26Z",
+		HomeTeam:    &football.Team{ID: 1765, Name: "Fluminense FC"},
+		AwayTeam:    &football.Team{ID: 6684, Name: "SC Internacional"},
+		Score: &football.Score{
+			Winner:   "AWAY_TEAM",
+			Duration: "REGULAR",
+			FullTime: football.Time{HomeTeam: 0, AwayTeam: 3},
+			HalfTime: football.Time{HomeTeam: 0, AwayTeam: 3},
+		},
+	}
+}
+
+func row(schema *Schema, v interface{}) map[string]interface{} {
+	values := map[string]interface{}{}
+	for i, value := range schema.Row(v) {
+		values[schema.Columns[i].Name] = value
+	}
+	return values
+}
+
+func TestSchemas_AreDocumented(t *testing.T) {
+	for _, schema := range []*Schema{MatchSchema, TableSchema, ScorerSchema, TeamSchema, PlayerSchema} {
+		names := map[string]bool{}
+		for _, column := range schema.Columns {
+			assert.NotEmpty(t, column.Description, column.Name)
+			assert.False(t, names[column.Name], column.Name)
+			names[column.Name] = true
+		}
+		assert.Equal(t, len(schema.Columns), len(schema.values))
+	}
+}
+
+func TestMatchSchema(t *testing.T) {
+	values := row(MatchSchema, testMatch())
+
+	assert.Equal(t, int64(204950), values["id"])
+	assert.Equal(t, "Fluminense FC", values["homeTeam.name"])
+	assert.Equal(t, int64(6684), values["awayTeam.id"])
+	assert.Equal(t, "Série A", values["competition.name"])
+	assert.Equal(t, int64(0), values["score.fullTime.homeTeam"])
+	assert.Equal(t, int64(3), values["score.fullTime.awayTeam"])
+	assert.Nil(t, values["score.extraTime.homeTeam"])
+	assert.Nil(t, values["score.penalties.awayTeam"])
+	assert.Nil(t, values["group"])
+	assert.Nil(t, values["attendance"])
+}
+
+func TestMatchSchema_ScoreNullBeforeKickoff(t *testing.T) {
+	match := testMatch()
+	match.Status = string(football.StatusScheduled)
+	match.Score = &football.Score{}
+	match.Competition, match.Season, match.HomeTeam = nil, nil, nil
+
+	values := row(MatchSchema, match)
+
+	assert.Nil(t, values["score.fullTime.homeTeam"])
+	assert.Nil(t, values["score.halfTime.awayTeam"])
+	assert.Nil(t, values["competition.id"])
+	assert.Nil(t, values["homeTeam.name"])
+}
+
+func TestMatchSchema_PenaltyShootout(t *testing.T) {
+	match := testMatch()
+	match.Score = &football.Score{
+		Duration:  "PENALTY_SHOOTOUT",
+		FullTime:  football.Time{HomeTeam: 1, AwayTeam: 1},
+		ExtraTime: football.Time{HomeTeam: 0, AwayTeam: 0},
+		Penalties: football.Time{HomeTeam: 4, AwayTeam: 3},
+	}
+
+	values := row(MatchSchema, match)
+
+	assert.Equal(t, int64(0), values["score.extraTime.homeTeam"])
+	assert.Equal(t, int64(4), values["score.penalties.homeTeam"])
+	assert.Equal(t, int64(3), values["score.penalties.awayTeam"])
+}
+
+func TestTableSchema(t *testing.T) {
+	values := row(TableSchema, football.Table{
+		Position: 1,
+		Team:     football.Team{ID: 1783, Name: "CR Flamengo"},
+		Points:   71,
+	})
+
+	assert.Equal(t, int64(1), values["position"])
+	assert.Equal(t, "CR Flamengo", values["team.name"])
+	assert.Equal(t, int64(71), values["points"])
+	assert.Equal(t, int64(0), values["lost"])
+}
@@ -0,0 +1,113 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// Writer writes rows of a schema to a file format.
+type Writer interface {
+	// Write writes a row, as returned by Schema.Row.
+	Write(row []interface{}) error
+	// Close flushes the rows written. It does not close the underlying
+	// io.Writer.
+	Close() error
+}
+
+// CSVWriter writes rows as CSV, with a header of the column names. Nulls
+// are written as empty fields.
+type CSVWriter struct {
+	w      *csv.Writer
+	schema *Schema
+	header bool
+}
+
+// NewCSVWriter returns a CSV writer of schema rows to w.
+func NewCSVWriter(w io.Writer, schema *Schema) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w), schema: schema}
+}
+
+func (c *CSVWriter) Write(row []interface{}) error {
+	if !c.header {
+		if err := c.w.Write(c.schema.Names()); err != nil {
+			return err
+		}
+		c.header = true
+	}
+
+	record := make([]string, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			record[i] = v
+		case int64:
+			record[i] = strconv.FormatInt(v, 10)
+		default:
+			return fmt.Errorf("Unsupported value %v in column %s", value, c.schema.Columns[i].Name)
+		}
+	}
+
+	return c.w.Write(record)
+}
+
+// Close writes the header if no row was written and flushes.
+func (c *CSVWriter) Close() error {
+	if !c.header {
+		if err := c.w.Write(c.schema.Names()); err != nil {
+			return err
+		}
+		c.header = true
+	}
+
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// ParquetWriter writes rows as a Snappy compressed Parquet file. Rows are
+// flushed to w every row group, so memory use is bounded by the row group
+// size, not by the number of rows.
+type ParquetWriter struct {
+	w *writer.CSVWriter
+}
+
+// ParquetRowGroupSize is the approximate size in bytes of the row groups
+// buffered before being written.
+var ParquetRowGroupSize int64 = 16 * 1024 * 1024
+
+// NewParquetWriter returns a Parquet writer of schema rows to w. The file
+// is complete once Close returns.
+func NewParquetWriter(w io.Writer, schema *Schema) (*ParquetWriter, error) {
+	metadata := make([]string, len(schema.Columns))
+	for i, column := range schema.Columns {
+		switch column.Kind {
+		case Int:
+			metadata[i] = fmt.Sprintf("name=%s, type=INT64, repetitiontype=OPTIONAL", column.Name)
+		default:
+			metadata[i] = fmt.Sprintf("name=%s, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL", column.Name)
+		}
+	}
+
+	pw, err := writer.NewCSVWriterFromWriter(metadata, w, 1)
+	if err != nil {
+		return nil, err
+	}
+	pw.RowGroupSize = ParquetRowGroupSize
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+
+	return &ParquetWriter{w: pw}, nil
+}
+
+func (p *ParquetWriter) Write(row []interface{}) error {
+	return p.w.Write(row)
+}
+
+// Close writes the last row group and the file footer.
+func (p *ParquetWriter) Close() error {
+	return p.w.WriteStop()
+}
@@ -0,0 +1,96 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var out bytes.Buffer
+
+	err := WriteScorers(NewCSVWriter(&out, ScorerSchema), []football.Scorer{
+		{
+			Player:        football.Player{ID: 1, Name: "Gabriel Barbosa", Position: "Attacker"},
+			Team:          football.Team{ID: 1783, Name: "CR Flamengo, RJ"},
+			NumberOfGoals: 14,
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "player.id,player.name,player.nationality,player.position,team.id,team.name,numberOfGoals\n"+
+		"1,Gabriel Barbosa,,Attacker,1783,\"CR Flamengo, RJ\",14\n", out.String())
+}
+
+func TestCSVWriter_HeaderWithoutRows(t *testing.T) {
+	var out bytes.Buffer
+
+	err := WriteTables(NewCSVWriter(&out, TableSchema), nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "position,team.id,team.name,playedGames,won,draw,lost,points,goalsFor,goalsAgainst,goalDifference\n", out.String())
+}
+
+func TestParquetWriter(t *testing.T) {
+	var out bytes.Buffer
+
+	w, err := NewParquetWriter(&out, MatchSchema)
+	assert.Nil(t, err)
+
+	scheduled := testMatch()
+	scheduled.ID = 2
+	scheduled.Status = string(football.StatusScheduled)
+	assert.Nil(t, WriteMatches(w, []football.Match{testMatch(), scheduled}))
+
+	file, err := buffer.NewBufferFile(out.Bytes())
+	assert.Nil(t, err)
+
+	r, err := reader.NewParquetReader(file, nil, 1)
+	assert.Nil(t, err)
+	defer r.ReadStop()
+
+	assert.Equal(t, int64(2), r.GetNumRows())
+
+	// The reader replaces the schema names with Go identifiers and keeps
+	// the names stored in the file as external names.
+	names := []string{}
+	for i := 1; i < len(r.Footer.Schema); i++ {
+		names = append(names, r.SchemaHandler.GetExName(i))
+	}
+	assert.Equal(t, MatchSchema.Names(), names)
+
+	column := func(name string) []interface{} {
+		for i, n := range names {
+			if n == name {
+				values, _, _, err := r.ReadColumnByIndex(int64(i), 2)
+				assert.Nil(t, err)
+				return values
+			}
+		}
+		t.Fatalf("no column %s", name)
+		return nil
+	}
+
+	assert.Equal(t, []interface{}{int64(204950), int64(2)}, column("id"))
+	assert.Equal(t, []interface{}{"Fluminense FC", "Fluminense FC"}, column("homeTeam.name"))
+	assert.Equal(t, []interface{}{int64(3), nil}, column("score.fullTime.awayTeam"))
+}
+
+func TestParquetWriter_Teams(t *testing.T) {
+	var out bytes.Buffer
+
+	w, err := NewParquetWriter(&out, TeamSchema)
+	assert.Nil(t, err)
+	assert.Nil(t, WriteTeams(w, []football.Team{{ID: 1765, Name: "Fluminense FC", Founded: 1902}}))
+
+	file, _ := buffer.NewBufferFile(out.Bytes())
+	r, err := reader.NewParquetReader(file, nil, 1)
+	assert.Nil(t, err)
+	defer r.ReadStop()
+
+	assert.Equal(t, int64(1), r.GetNumRows())
+}
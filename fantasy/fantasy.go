@@ -0,0 +1,141 @@
+// Package fantasy scores the players of a match with configurable fantasy
+// rules, keeping a breakdown of how each point was earned.
+package fantasy
+
+import (
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// Reasons points are awarded for.
+const (
+	ReasonMinutes       = "MINUTES"
+	ReasonGoal          = "GOAL"
+	ReasonAssist        = "ASSIST"
+	ReasonCleanSheet    = "CLEAN_SHEET"
+	ReasonGoalsConceded = "GOALS_CONCEDED"
+	ReasonYellowCard    = "YELLOW_CARD"
+	ReasonRedCard       = "RED_CARD"
+	ReasonOwnGoal       = "OWN_GOAL"
+)
+
+// Item is a line of the breakdown of the points of a player.
+type Item struct {
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+	Points int    `json:"points"`
+}
+
+// PlayerPoints holds the points earned by a player in a match.
+type PlayerPoints struct {
+	Player    football.Player `json:"player"`
+	Team      football.Team   `json:"team"`
+	Position  string          `json:"position"`
+	Points    int             `json:"points"`
+	Breakdown []Item          `json:"breakdown"`
+}
+
+// Engine scores matches with a set of rules.
+type Engine struct {
+	Rules Rules
+
+	// Positions overrides the position of players by ID, for matches whose
+	// lineups do not carry them. It can be filled from Team.Squad.
+	Positions map[int64]string
+}
+
+// NewEngine returns an Engine scoring with rules.
+func NewEngine(rules Rules) *Engine {
+	return &Engine{Rules: rules, Positions: map[int64]string{}}
+}
+
+// AddSquad records the positions of the players of team.
+func (e *Engine) AddSquad(team football.Team) {
+	if team.Squad == nil {
+		return
+	}
+	if e.Positions == nil {
+		e.Positions = map[int64]string{}
+	}
+	for _, player := range *team.Squad {
+		if len(player.Position) > 0 {
+			e.Positions[player.ID] = player.Position
+		}
+	}
+}
+
+// Score returns the points of every player that appeared in match. The
+// match must carry its lineups, substitutions, goals and bookings.
+func (e *Engine) Score(match football.Match) []PlayerPoints {
+	timeline := match.Timeline()
+	result := []PlayerPoints{}
+
+	for _, appearance := range match.Appearances() {
+		position := appearance.Player.Position
+		if override, ok := e.Positions[appearance.Player.ID]; ok {
+			position = override
+		}
+
+		points := PlayerPoints{
+			Player:    appearance.Player,
+			Team:      appearance.Team,
+			Position:  position,
+			Breakdown: []Item{},
+		}
+
+		add := func(reason string, count, each int) {
+			if count == 0 || each == 0 {
+				return
+			}
+			points.Breakdown = append(points.Breakdown, Item{Reason: reason, Count: count, Points: count * each})
+			points.Points += count * each
+		}
+
+		add(ReasonMinutes, 1, e.minutesPoints(appearance.Minutes))
+		add(ReasonGoal, appearance.Goals, e.Rules.Goal.For(position))
+		add(ReasonAssist, appearance.Assists, e.Rules.Assist)
+
+		conceded := concededOnPitch(match, timeline, appearance)
+		if conceded == 0 && appearance.Minutes >= e.Rules.CleanSheetMinutes {
+			add(ReasonCleanSheet, 1, e.Rules.CleanSheet.For(position))
+		}
+		if e.Rules.GoalsConceded.Per > 0 {
+			add(ReasonGoalsConceded, conceded/e.Rules.GoalsConceded.Per, e.Rules.GoalsConceded.Points.For(position))
+		}
+
+		add(ReasonYellowCard, appearance.YellowCards, e.Rules.YellowCard)
+		add(ReasonRedCard, appearance.RedCards, e.Rules.RedCard)
+		add(ReasonOwnGoal, appearance.OwnGoals, e.Rules.OwnGoal)
+
+		result = append(result, points)
+	}
+
+	return result
+}
+
+func (e *Engine) minutesPoints(minutes int) int {
+	points, reached := 0, 0
+	for _, threshold := range e.Rules.Minutes {
+		if minutes >= threshold.Minutes && threshold.Minutes >= reached {
+			points, reached = threshold.Points, threshold.Minutes
+		}
+	}
+	return points
+}
+
+// concededOnPitch counts the goals scored against the team of the player
+// while the player was on the pitch. Goals in added time count against
+// players who finished the match.
+func concededOnPitch(match football.Match, timeline []football.TimelineEvent, appearance football.Appearance) int {
+	finished := appearance.Off >= match.Length()
+
+	conceded := 0
+	for _, event := range timeline {
+		if event.Type != football.TimelineGoal || event.Team.ID == appearance.Team.ID {
+			continue
+		}
+		if event.Minute >= appearance.On && (event.Minute < appearance.Off || finished) {
+			conceded++
+		}
+	}
+	return conceded
+}
@@ -0,0 +1,94 @@
+package fantasy
+
+import (
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func player(id int64, position string) football.Player {
+	return football.Player{ID: id, Position: position}
+}
+
+func fixture() football.Match {
+	home := football.Team{ID: 1, Lineup: &[]football.Player{
+		player(1, "Goalkeeper"),
+		player(2, "Defender"),
+		player(3, "Attacker"),
+	}}
+	away := football.Team{ID: 2, Lineup: &[]football.Player{
+		player(11, "Goalkeeper"),
+		player(12, "Midfielder"),
+	}}
+
+	return football.Match{
+		Status:   string(football.StatusFinished),
+		Score:    &football.Score{Duration: "REGULAR"},
+		HomeTeam: &home,
+		AwayTeam: &away,
+		Goals: []football.Goals{
+			{Minute: 30, Team: football.Team{ID: 2}, Scorer: football.Player{ID: 12}},
+			{Minute: 80, Team: football.Team{ID: 1}, Scorer: football.Player{ID: 3}, Assist: football.Player{ID: 2}},
+		},
+		Bookings: []football.Bookings{
+			{Minute: 50, Team: football.Team{ID: 1}, Player: football.Player{ID: 2}, Card: "YELLOW_CARD"},
+		},
+		Substitutions: []football.Substitutions{
+			{Minute: 85, Team: football.Team{ID: 1}, PlayerOut: football.Player{ID: 3}, PlayerIn: football.Player{ID: 4}},
+		},
+	}
+}
+
+func byPlayer(points []PlayerPoints) map[int64]PlayerPoints {
+	result := map[int64]PlayerPoints{}
+	for _, p := range points {
+		result[p.Player.ID] = p
+	}
+	return result
+}
+
+func TestEngine_Score(t *testing.T) {
+	points := byPlayer(NewEngine(DefaultRules).Score(fixture()))
+
+	assert.Len(t, points, 6)
+
+	assert.Equal(t, 2, points[1].Points)
+	assert.Equal(t, []Item{
+		{Reason: ReasonMinutes, Count: 1, Points: 2},
+		{Reason: ReasonAssist, Count: 1, Points: 3},
+		{Reason: ReasonYellowCard, Count: 1, Points: -1},
+	}, points[2].Breakdown)
+	assert.Equal(t, 4, points[2].Points)
+	assert.Equal(t, 6, points[3].Points)
+	assert.Equal(t, 1, points[4].Points)
+	assert.Equal(t, 2, points[11].Points)
+	assert.Equal(t, 7, points[12].Points)
+}
+
+func TestEngine_CleanSheetAndConceded(t *testing.T) {
+	match := fixture()
+	match.Goals = []football.Goals{
+		{Minute: 10, Team: football.Team{ID: 1}, Scorer: football.Player{ID: 3}},
+		{Minute: 20, Team: football.Team{ID: 1}, Scorer: football.Player{ID: 3}},
+		{Minute: 90, Team: football.Team{ID: 1}, ExtraTime: 4.0, Scorer: football.Player{ID: 4}},
+	}
+
+	engine := NewEngine(DefaultRules)
+	engine.AddSquad(football.Team{Squad: &[]football.Player{player(4, "Defender")}})
+
+	points := byPlayer(engine.Score(match))
+
+	assert.Equal(t, []Item{
+		{Reason: ReasonMinutes, Count: 1, Points: 2},
+		{Reason: ReasonCleanSheet, Count: 1, Points: 4},
+	}, points[1].Breakdown)
+
+	assert.Equal(t, "Defender", points[4].Position)
+	assert.Equal(t, 1+6, points[4].Points)
+
+	assert.Equal(t, []Item{
+		{Reason: ReasonMinutes, Count: 1, Points: 2},
+		{Reason: ReasonGoalsConceded, Count: 1, Points: -1},
+	}, points[11].Breakdown)
+}
@@ -0,0 +1,118 @@
+package fantasy
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPosition is the key of per-position values applied to players
+// whose position has no value of its own.
+const DefaultPosition = "default"
+
+// PositionPoints holds points by player position, such as "Goalkeeper",
+// "Defender", "Midfielder" and "Attacker".
+type PositionPoints map[string]int
+
+// For returns the points of position, falling back to DefaultPosition.
+func (p PositionPoints) For(position string) int {
+	if points, ok := p[position]; ok {
+		return points
+	}
+	return p[DefaultPosition]
+}
+
+// MinutesThreshold awards points to players reaching a number of minutes.
+type MinutesThreshold struct {
+	Minutes int `json:"minutes" yaml:"minutes"`
+	Points  int `json:"points" yaml:"points"`
+}
+
+// ConcededRule awards points for every Per goals conceded while on the
+// pitch.
+type ConcededRule struct {
+	Per    int            `json:"per" yaml:"per"`
+	Points PositionPoints `json:"points" yaml:"points"`
+}
+
+// Rules describes how fantasy points are earned.
+type Rules struct {
+	Name              string             `json:"name" yaml:"name"`
+	Minutes           []MinutesThreshold `json:"minutes" yaml:"minutes"` // The highest threshold reached applies.
+	Goal              PositionPoints     `json:"goal" yaml:"goal"`
+	Assist            int                `json:"assist" yaml:"assist"`
+	CleanSheet        PositionPoints     `json:"cleanSheet" yaml:"cleanSheet"`
+	CleanSheetMinutes int                `json:"cleanSheetMinutes" yaml:"cleanSheetMinutes"` // Minutes needed for a clean sheet.
+	GoalsConceded     ConcededRule       `json:"goalsConceded" yaml:"goalsConceded"`
+	YellowCard        int                `json:"yellowCard" yaml:"yellowCard"`
+	RedCard           int                `json:"redCard" yaml:"redCard"`
+	OwnGoal           int                `json:"ownGoal" yaml:"ownGoal"`
+}
+
+// DefaultRules is a classic rule set.
+var DefaultRules = Rules{
+	Name: "default",
+	Minutes: []MinutesThreshold{
+		{Minutes: 1, Points: 1},
+		{Minutes: 60, Points: 2},
+	},
+	Goal: PositionPoints{
+		"Goalkeeper": 6,
+		"Defender":   6,
+		"Midfielder": 5,
+		"Attacker":   4,
+	},
+	Assist: 3,
+	CleanSheet: PositionPoints{
+		"Goalkeeper": 4,
+		"Defender":   4,
+		"Midfielder": 1,
+	},
+	CleanSheetMinutes: 60,
+	GoalsConceded: ConcededRule{
+		Per:    2,
+		Points: PositionPoints{"Goalkeeper": -1, "Defender": -1},
+	},
+	YellowCard: -1,
+	RedCard:    -3,
+	OwnGoal:    -2,
+}
+
+// ParseJSON parses rules in JSON.
+func ParseJSON(data []byte) (*Rules, error) {
+	rules := &Rules{}
+	if err := json.Unmarshal(data, rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ParseYAML parses rules in YAML.
+func ParseYAML(data []byte) (*Rules, error) {
+	rules := &Rules{}
+	if err := yaml.Unmarshal(data, rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// LoadRules reads rules from a .json, .yaml or .yml file.
+func LoadRules(path string) (*Rules, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ParseJSON(data)
+	case ".yaml", ".yml":
+		return ParseYAML(data)
+	default:
+		return nil, errors.New("Rules must be a .json, .yaml or .yml file")
+	}
+}
@@ -0,0 +1,67 @@
+package fantasy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJSON(t *testing.T) {
+	rules, err := ParseJSON([]byte(`{
+		"name": "office league",
+		"minutes": [{"minutes": 60, "points": 2}],
+		"goal": {"Attacker": 4, "default": 5},
+		"assist": 3,
+		"redCard": -3
+	}`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "office league", rules.Name)
+	assert.Equal(t, []MinutesThreshold{{Minutes: 60, Points: 2}}, rules.Minutes)
+	assert.Equal(t, 4, rules.Goal.For("Attacker"))
+	assert.Equal(t, 5, rules.Goal.For("Midfielder"))
+	assert.Equal(t, -3, rules.RedCard)
+}
+
+func TestParseYAML(t *testing.T) {
+	rules, err := ParseYAML([]byte(`
+name: office league
+goal:
+  Goalkeeper: 6
+cleanSheet:
+  Goalkeeper: 4
+cleanSheetMinutes: 60
+goalsConceded:
+  per: 2
+  points:
+    Goalkeeper: -1
+`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 6, rules.Goal.For("Goalkeeper"))
+	assert.Equal(t, 0, rules.Goal.For("Attacker"))
+	assert.Equal(t, 60, rules.CleanSheetMinutes)
+	assert.Equal(t, ConcededRule{Per: 2, Points: PositionPoints{"Goalkeeper": -1}}, rules.GoalsConceded)
+}
+
+func TestLoadRules(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fantasy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "rules.yml")
+	ioutil.WriteFile(path, []byte("name: yaml rules\nassist: 2\n"), 0644)
+
+	rules, err := LoadRules(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, rules.Assist)
+
+	path = filepath.Join(dir, "rules.txt")
+	ioutil.WriteFile(path, []byte("name: text rules\n"), 0644)
+
+	_, err = LoadRules(path)
+	assert.True(t, err != nil && err.Error() == "Rules must be a .json, .yaml or .yml file")
+}
@@ -9,8 +9,9 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-
-	"github.com/google/go-querystring/query"
+	"sort"
+	"strings"
+	"sync"
 )
 
 const (
@@ -19,8 +20,8 @@ const (
 
 type Client struct {
 	client  *http.Client // HTTP client used to communicate with the API.
-	BaseURL *url.URL
-	common  service // Reuse a single struct instead of allocating one for each service on the heap.
+	BaseURL *url.URL     // API URL requests are made against, APIURL by default.
+	common  service      // Reuse a single struct instead of allocating one for each service on the heap.
 
 	// Services used for talking to different parts of the Football API.
 	Areas        *AreaService
@@ -28,6 +29,32 @@ type Client struct {
 	Matches      *MatchService
 	Players      *PlayerService
 	Teams        *TeamService
+
+	// Plan is the subscription tier of the token. When set, competitions
+	// outside it are left out of CompetitionService.List and fail with
+	// ErrPlanRestricted without a request.
+	Plan Plan
+
+	// Concurrency bounds the requests batch helpers such as FindMany run
+	// at once. Defaults to DefaultConcurrency.
+	Concurrency int
+
+	// RateLimiter, when set, queues requests so they stay within the
+	// quota of the token.
+	RateLimiter *RateLimiter
+
+	// Snapshot, when set, serves every request from the snapshot instead
+	// of the API. No token is needed.
+	Snapshot *Snapshot
+
+	// Strict, when set, fails responses carrying fields the structs do not
+	// know with a *DecodeError, so API changes are noticed.
+	Strict bool
+
+	plans planTable
+
+	inflightMu sync.Mutex
+	inflight   map[string]*inflightCall
 }
 
 type service struct {
@@ -57,25 +84,164 @@ func NewClient(httpClient *http.Client) *Client {
 
 // Get performs a GET against the api
 func (c *Client) Get(path string, params interface{}, v interface{}) ([]byte, error) {
+	return c.GetContext(context.Background(), path, params, v)
+}
+
+// GetContext performs a GET against the api, waiting for the rate limiter
+// if the client has one. A response other than 200 is returned as an
+// *ErrorResponse.
+//
+// A response that does not fit v is returned with a *DecodeError, see
+// Client.Strict.
+//
+// Concurrent identical requests, same URL and headers, share one round
+// trip; each caller decodes its own copy of the response. A caller whose
+// ctx is done stops waiting at once, and the shared request is canceled
+// once every caller has stopped waiting.
+func (c *Client) GetContext(ctx context.Context, path string, params interface{}, v interface{}) ([]byte, error) {
+	if c.Snapshot != nil {
+		return c.getSnapshot(path, params, v)
+	}
+
 	if len(os.Getenv("FOOTBALL_API_TOKEN")) == 0 {
 		return nil, errors.New("You need to export the FOOTBALL_API_TOKEN")
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", strings.TrimSuffix(c.BaseURL.String(), "/"), path), nil)
+	if err != nil {
+		return nil, err
+	}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", APIURL, path), nil)
+	req.URL.RawQuery, err = encodeParams(params)
 	if err != nil {
 		return nil, err
 	}
 
-	if params != nil {
-		query, _ := query.Values(params)
+	req.Header = c.GetHeaders()
 
-		req.URL.RawQuery = query.Encode()
+	response, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
-	req.Header = c.GetHeaders()
+	if err := c.decode(path, response, v); err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// decode unmarshals the response of path into v. Type mismatches, and in
+// strict mode unknown fields, are returned as a *DecodeError once v holds
+// everything that did fit.
+func (c *Client) decode(path string, response []byte, v interface{}) error {
+	if response == nil || v == nil {
+		return nil
+	}
+
+	err := json.Unmarshal(response, &v)
+	if err == nil && !c.Strict {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if err != nil && !errors.As(err, &typeErr) {
+		return err
+	}
+
+	drifts, checkErr := CheckSchema(response, v)
+	if checkErr != nil {
+		return checkErr
+	}
+
+	reported := []Drift{}
+	for _, drift := range drifts {
+		if c.Strict || drift.Kind == TypeMismatch {
+			reported = append(reported, drift)
+		}
+	}
+
+	if len(reported) == 0 {
+		return err
+	}
+
+	return &DecodeError{Endpoint: Endpoint(path), Drifts: reported}
+}
+
+// inflightCall is a round trip shared by identical concurrent requests.
+type inflightCall struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+
+	response []byte
+	err      error
+}
+
+// do sends req, joining the identical request in flight if there is one.
+func (c *Client) do(ctx context.Context, req *http.Request) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key := requestKey(req)
+
+	c.inflightMu.Lock()
+	if c.inflight == nil {
+		c.inflight = map[string]*inflightCall{}
+	}
+
+	call, ok := c.inflight[key]
+	if !ok {
+		// The round trip is detached from the first caller, so it
+		// survives as long as anyone waits for it.
+		callCtx, cancel := context.WithCancel(context.Background())
+		call = &inflightCall{done: make(chan struct{}), cancel: cancel}
+		c.inflight[key] = call
+
+		go c.roundTrip(callCtx, key, req, call)
+	}
+	call.waiters++
+	c.inflightMu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.response, call.err
+	case <-ctx.Done():
+		c.inflightMu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			if c.inflight[key] == call {
+				delete(c.inflight, key)
+			}
+		}
+		c.inflightMu.Unlock()
+
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Client) roundTrip(ctx context.Context, key string, req *http.Request, call *inflightCall) {
+	call.response, call.err = c.send(ctx, req)
+
+	c.inflightMu.Lock()
+	if c.inflight[key] == call {
+		delete(c.inflight, key)
+	}
+	c.inflightMu.Unlock()
+
+	call.cancel()
+	close(call.done)
+}
+
+// send waits for the rate limiter and makes the round trip.
+func (c *Client) send(ctx context.Context, req *http.Request) ([]byte, error) {
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
 
 	res, err := c.client.Do(req.WithContext(ctx))
 	if err != nil {
@@ -90,17 +256,62 @@ func (c *Client) Get(path string, params interface{}, v interface{}) ([]byte, er
 	}
 
 	if res.StatusCode != 200 {
-		err := errors.New(res.Status)
+		return nil, &ErrorResponse{StatusCode: res.StatusCode, Status: res.Status, Body: response}
+	}
+
+	return response, nil
+}
+
+// requestKey identifies identical requests by method, URL and headers.
+func requestKey(req *http.Request) string {
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var key strings.Builder
+	key.WriteString(req.Method + " " + req.URL.String())
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			key.WriteString("\n" + name + ": " + value)
+		}
+	}
+
+	return key.String()
+}
+
+// getSnapshot serves a Get from the client snapshot.
+func (c *Client) getSnapshot(path string, params interface{}, v interface{}) ([]byte, error) {
+	key, err := SnapshotKey(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.Snapshot.Lookup(key)
+	if err != nil {
 		return nil, err
 	}
 
-	if response != nil {
-		json.Unmarshal(response, &v)
+	if err := c.decode(path, response, v); err != nil {
+		return response, err
 	}
 
 	return response, nil
 }
 
+// ErrorResponse is returned for API responses other than 200.
+type ErrorResponse struct {
+	StatusCode int
+	Status     string
+	// Body is the response body, usually a JSON message from the API.
+	Body []byte
+}
+
+func (e *ErrorResponse) Error() string {
+	return e.Status
+}
+
 func (client *Client) GetHeaders() http.Header {
 	headers := &http.Header{}
 
@@ -1,10 +1,14 @@
 package football
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -56,3 +60,131 @@ func ErrorContains(out error, want string) bool {
 	return strings.Contains(out.Error(), want)
 }
 
+
+func TestClient_GetContextErrorResponse(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/competitions/PL/standings", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2021", r.URL.Query().Get("season"))
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"message": "You reached your request limit.", "errorCode": 429}`)
+	})
+
+	client := NewClient(httpClient)
+	_, err := client.GetContext(context.Background(), "competitions/PL/standings", url.Values{"season": {"2021"}}, nil)
+
+	response, ok := err.(*ErrorResponse)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	assert.Equal(t, "429 Too Many Requests", response.Error())
+	assert.Contains(t, string(response.Body), "request limit")
+}
+
+func TestClient_BaseURL(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/api/v2/areas", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 0}`)
+	})
+
+	client := NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/api/v2/")
+
+	_, err := client.GetContext(context.Background(), "areas", nil, nil)
+	assert.Nil(t, err)
+}
+
+func TestClient_CollapsesIdenticalRequests(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	var hits int32
+	release := make(chan struct{})
+	mux.HandleFunc("/v2/competitions/PL/standings", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		fmt.Fprint(w, `{"competition": {"id": 2021, "code": "PL"}}`)
+	})
+
+	client := NewClient(httpClient)
+	results := make([]*CompetitionStandings, 10)
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			standings, err := client.Competitions.Standings(context.Background(), "PL", nil)
+			assert.Nil(t, err)
+			results[i] = standings
+		}(i)
+	}
+
+	for atomic.LoadInt32(&hits) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+	for _, standings := range results {
+		assert.Equal(t, "PL", standings.Competition.Code)
+	}
+	// Every caller gets its own copy.
+	assert.True(t, results[0] != results[1])
+}
+
+func TestClient_CollapsedRequestCancellation(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	canceled := make(chan struct{})
+	release := make(chan struct{})
+	mux.HandleFunc("/v2/areas", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+			fmt.Fprint(w, `{"count": 1}`)
+		case <-r.Context().Done():
+			close(canceled)
+		}
+	})
+
+	client := NewClient(httpClient)
+
+	// One caller leaving does not cancel the request of the others.
+	leaving, leave := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() {
+		_, err := client.Areas.List(leaving)
+		errs <- err
+	}()
+	go func() {
+		_, err := client.Areas.List(context.Background())
+		errs <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	leave()
+	assert.Equal(t, context.Canceled, <-errs)
+
+	close(release)
+	assert.Nil(t, <-errs)
+
+	// The request is canceled once every caller left.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	release = make(chan struct{})
+
+	_, err := client.Areas.List(ctx)
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Error("request was not canceled")
+	}
+}
@@ -0,0 +1,74 @@
+package football
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Groups takes a Competition ID and returns the names of the groups of
+// its group stages, in the order they are listed by the standings.
+// https://www.football-data.org/documentation/api
+func (s *CompetitionService) Groups(ctx context.Context, id string, filters *CompetitionStandingsFiltersOptions) ([]string, error) {
+	competitionStandings, err := s.Standings(ctx, id, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []string{}
+	seen := map[string]bool{}
+	for _, standing := range competitionStandings.Standings {
+		if len(standing.Group) == 0 || seen[standing.Group] {
+			continue
+		}
+		seen[standing.Group] = true
+		groups = append(groups, standing.Group)
+	}
+
+	return groups, nil
+}
+
+// GroupStandings takes a Competition ID and a group name and returns the
+// standing of that group. The TOTAL standing is returned unless filters
+// ask for another standing type.
+// https://www.football-data.org/documentation/api
+func (s *CompetitionService) GroupStandings(ctx context.Context, id string, group string, filters *CompetitionStandingsFiltersOptions) (*Standing, error) {
+	if len(group) == 0 {
+		return nil, errors.New("Group is required")
+	}
+
+	competitionStandings, err := s.Standings(ctx, id, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	standingType := StandingTypeTotal
+	if filters != nil && len(filters.StandingType) > 0 {
+		standingType = filters.StandingType
+	}
+
+	for _, standing := range competitionStandings.Standings {
+		if standing.Group == group && standing.Type == string(standingType) {
+			return &standing, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Group %s not found", group)
+}
+
+// GroupMatches takes a Competition ID and a group name and returns the
+// matches of that group.
+// https://www.football-data.org/documentation/api
+func (s *CompetitionService) GroupMatches(ctx context.Context, id string, group string, filters *CompetitionMatchesFiltersOptions) (*CompetitionMatches, error) {
+	if len(group) == 0 {
+		return nil, errors.New("Group is required")
+	}
+
+	groupFilters := CompetitionMatchesFiltersOptions{}
+	if filters != nil {
+		groupFilters = *filters
+	}
+	groupFilters.Group = group
+
+	return s.Matches(ctx, id, &groupFilters)
+}
@@ -0,0 +1,150 @@
+package football
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const groupStandingsResponse = `{
+	"competition": {
+		"id": 2018,
+		"name": "European Championship",
+		"code": "EC"
+	},
+	"season": {
+		"id": 507,
+		"startDate": "2021-06-11",
+		"endDate": "2021-07-11",
+		"currentMatchday": 3
+	},
+	"standings": [
+		{
+			"stage": "GROUP_STAGE",
+			"type": "TOTAL",
+			"group": "GROUP_A",
+			"table": [
+				{
+					"position": 1,
+					"team": {
+						"id": 803,
+						"name": "Italy"
+					},
+					"playedGames": 3,
+					"won": 3,
+					"points": 9,
+					"goalsFor": 7,
+					"goalDifference": 7
+				}
+			]
+		},
+		{
+			"stage": "GROUP_STAGE",
+			"type": "HOME",
+			"group": "GROUP_A",
+			"table": []
+		},
+		{
+			"stage": "GROUP_STAGE",
+			"type": "TOTAL",
+			"group": "GROUP_B",
+			"table": []
+		}
+	]
+}`
+
+func TestCompetitionService_Groups(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/competitions/2018/standings", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assert.Equal(t, "2020", r.URL.Query().Get("season"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, groupStandingsResponse)
+	})
+
+	ctx := context.Background()
+	client := NewClient(httpClient)
+	groups, err := client.Competitions.Groups(ctx, "2018", &CompetitionStandingsFiltersOptions{Season: "2020"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"GROUP_A", "GROUP_B"}, groups)
+}
+
+func TestCompetitionService_GroupStandings(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/competitions/2018/standings", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, groupStandingsResponse)
+	})
+
+	expected := &Standing{
+		Stage: "GROUP_STAGE",
+		Type:  "TOTAL",
+		Group: "GROUP_A",
+		Table: []Table{
+			{
+				Position:       1,
+				Team:           Team{ID: 803, Name: "Italy"},
+				PlayedGames:    3,
+				Won:            3,
+				Points:         9,
+				GoalsFor:       7,
+				GoalDifference: 7,
+			},
+		},
+	}
+
+	ctx := context.Background()
+	client := NewClient(httpClient)
+	standing, err := client.Competitions.GroupStandings(ctx, "2018", "GROUP_A", nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, standing)
+
+	_, err = client.Competitions.GroupStandings(ctx, "2018", "GROUP_Z", nil)
+	assert.True(t, ErrorContains(err, "Group GROUP_Z not found"))
+}
+
+func TestCompetitionService_GroupMatches(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/competitions/2018/matches", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assert.Equal(t, "GROUP_A", r.URL.Query().Get("group"))
+		assert.Equal(t, "SCHEDULED", r.URL.Query().Get("status"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{
+			"count": 1,
+			"matches": [
+				{
+					"id": 285418,
+					"status": "SCHEDULED",
+					"group": "GROUP_A"
+				}
+			]
+		}`)
+	})
+
+	expected := &CompetitionMatches{
+		Count: 1,
+		Matches: []Match{
+			{ID: 285418, Status: "SCHEDULED", Group: "GROUP_A"},
+		},
+	}
+
+	ctx := context.Background()
+	client := NewClient(httpClient)
+	matches, err := client.Competitions.GroupMatches(ctx, "2018", "GROUP_A", &CompetitionMatchesFiltersOptions{Status: StatusScheduled})
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, matches)
+}
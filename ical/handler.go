@@ -0,0 +1,86 @@
+package ical
+
+import (
+	"bytes"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// Handler serves a feed per team at /{teamID}.ics, relative to where it is
+// mounted, for example:
+//
+//	http.Handle("/teams/", http.StripPrefix("/teams", &ical.Handler{Client: client}))
+type Handler struct {
+	Client  *football.Client
+	Options Options
+	// MaxAge is sent as the Cache-Control max-age, in seconds, so
+	// calendar clients do not poll the API too often. Defaults to an hour.
+	MaxAge int
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := path.Base(r.URL.Path)
+	if !strings.HasSuffix(name, ".ics") {
+		http.NotFound(w, r)
+		return
+	}
+
+	id, err := strconv.Atoi(strings.TrimSuffix(name, ".ics"))
+	if err != nil || id <= 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	matches, err := h.Client.Teams.Matches(r.Context(), strconv.Itoa(id), nil)
+	if response, ok := err.(*football.ErrorResponse); ok && response.StatusCode == http.StatusNotFound {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	options := h.Options
+	if options.Name == "" {
+		options.Name = teamCalendarName(id, matches.Matches)
+	}
+
+	var body bytes.Buffer
+	if err := EncodeTeamMatches(&body, matches, &options); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	maxAge := h.MaxAge
+	if maxAge == 0 {
+		maxAge = 3600
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="`+name+`"`)
+	w.Header().Set("Cache-Control", "max-age="+strconv.Itoa(maxAge))
+	w.Write(body.Bytes())
+}
+
+// teamCalendarName names the feed after the team, as found in its matches.
+func teamCalendarName(id int, matches []football.Match) string {
+	for _, match := range matches {
+		for _, team := range []*football.Team{match.HomeTeam, match.AwayTeam} {
+			if team != nil && team.ID == id && team.Name != "" {
+				return team.Name + " matches"
+			}
+		}
+	}
+	return "Team " + strconv.Itoa(id) + " matches"
+}
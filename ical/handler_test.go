@@ -0,0 +1,57 @@
+package ical
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/teams/1765/matches", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 1, "matches": [
+			{"id": 1, "utcDate": "2021-08-13T19:00:00Z", "status": "SCHEDULED",
+				"homeTeam": {"id": 1765, "name": "Fluminense FC"}, "awayTeam": {"id": 6684, "name": "SC Internacional"}}
+		]}`)
+	})
+	mux.HandleFunc("/v2/teams/2/matches", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	api := httptest.NewServer(mux)
+	defer api.Close()
+
+	client := football.NewClient(nil)
+	client.BaseURL, _ = url.Parse(api.URL + "/v2")
+	handler := http.StripPrefix("/teams", &Handler{Client: client})
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/teams/1765.ics", http.StatusOK},
+		{"/teams/1765", http.StatusNotFound},
+		{"/teams/abc.ics", http.StatusNotFound},
+		{"/teams/1.ics", http.StatusNotFound},
+		{"/teams/2.ics", http.StatusBadGateway},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest("GET", test.path, nil))
+
+			assert.Equal(t, test.status, rec.Code)
+			if test.status == http.StatusOK {
+				assert.Equal(t, "text/calendar; charset=utf-8", rec.Header().Get("Content-Type"))
+				assert.Equal(t, "max-age=3600", rec.Header().Get("Cache-Control"))
+				assert.Contains(t, rec.Body.String(), "X-WR-CALNAME:Fluminense FC matches\r\n")
+				assert.Contains(t, rec.Body.String(), "UID:match-1@football-data.org\r\n")
+			}
+		})
+	}
+}
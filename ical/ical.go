@@ -0,0 +1,247 @@
+// Package ical encodes matches as RFC 5545 iCalendar feeds, for calendar
+// subscriptions.
+//
+// Every match becomes a VEVENT whose UID derives from the match ID and
+// whose SEQUENCE derives from its LastUpdated time, so calendar clients
+// replace the event when a match is rescheduled, postponed or canceled.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// ProdID identifies the producer of the calendars.
+const ProdID = "-//football-data-sdk//ical//EN"
+
+const (
+	dateTimeFormat = "20060102T150405Z"
+	lineLength     = 75
+)
+
+// sequenceEpoch is subtracted from LastUpdated so SEQUENCE fits in the 32
+// bits calendar clients allow.
+var sequenceEpoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Options configures a calendar.
+type Options struct {
+	// Name is shown by calendar clients as the calendar title.
+	Name string
+	// Duration is the length of the events. Defaults to 2 hours.
+	Duration time.Duration
+	// Now stamps matches without LastUpdated. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// EncodeTeamMatches writes the matches of a team as a calendar to w.
+func EncodeTeamMatches(w io.Writer, matches *football.TeamMatches, opts *Options) error {
+	return Encode(w, matches.Matches, opts)
+}
+
+// EncodeCompetitionMatches writes the matches of a competition as a
+// calendar to w. The calendar is named after the competition unless a name
+// is given.
+func EncodeCompetitionMatches(w io.Writer, matches *football.CompetitionMatches, opts *Options) error {
+	options := Options{}
+	if opts != nil {
+		options = *opts
+	}
+	if options.Name == "" {
+		options.Name = matches.Competition.Name
+	}
+
+	list := make([]football.Match, len(matches.Matches))
+	for i, match := range matches.Matches {
+		if match.Competition == nil {
+			competition := matches.Competition
+			match.Competition = &competition
+		}
+		list[i] = match
+	}
+
+	return Encode(w, list, &options)
+}
+
+// Encode writes the matches as a calendar to w. Matches without a kick-off
+// time are left out.
+func Encode(w io.Writer, matches []football.Match, opts *Options) error {
+	options := Options{}
+	if opts != nil {
+		options = *opts
+	}
+	if options.Duration == 0 {
+		options.Duration = 2 * time.Hour
+	}
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+
+	out := &writer{w: bufio.NewWriter(w)}
+
+	out.line("BEGIN:VCALENDAR")
+	out.line("VERSION:2.0")
+	out.line("PRODID:" + ProdID)
+	out.line("CALSCALE:GREGORIAN")
+	out.line("METHOD:PUBLISH")
+	if options.Name != "" {
+		out.line("X-WR-CALNAME:" + escape(options.Name))
+	}
+
+	for _, match := range matches {
+		encodeMatch(out, match, &options)
+	}
+
+	out.line("END:VCALENDAR")
+
+	if out.err != nil {
+		return out.err
+	}
+	return out.w.Flush()
+}
+
+// Marshal returns the matches as a calendar.
+func Marshal(matches []football.Match, opts *Options) ([]byte, error) {
+	var b strings.Builder
+	if err := Encode(&b, matches, opts); err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
+
+// UID returns the stable event UID of a match.
+func UID(match football.Match) string {
+	return fmt.Sprintf("match-%d@football-data.org", match.ID)
+}
+
+func encodeMatch(out *writer, match football.Match, options *Options) {
+	kickoff, err := time.Parse(time.RFC3339, match.UtcDate)
+	if err != nil {
+		return
+	}
+
+	updated, err := time.Parse(time.RFC3339, match.LastUpdated)
+	if err != nil {
+		updated = options.Now()
+	}
+
+	out.line("BEGIN:VEVENT")
+	out.line("UID:" + UID(match))
+	out.line("DTSTAMP:" + updated.UTC().Format(dateTimeFormat))
+	out.line("LAST-MODIFIED:" + updated.UTC().Format(dateTimeFormat))
+	out.line("SEQUENCE:" + strconv.FormatInt(int64(updated.Sub(sequenceEpoch)/time.Second), 10))
+	out.line("DTSTART:" + kickoff.UTC().Format(dateTimeFormat))
+	out.line("DTEND:" + kickoff.Add(options.Duration).UTC().Format(dateTimeFormat))
+	out.line("SUMMARY:" + escape(summary(match)))
+	if match.Venue != "" {
+		out.line("LOCATION:" + escape(match.Venue))
+	}
+	out.line("DESCRIPTION:" + escape(description(match)))
+	out.line("STATUS:" + status(match))
+	out.line("END:VEVENT")
+}
+
+// summary returns "Home vs Away", or "Home 2-1 Away" once the match is
+// finished.
+func summary(match football.Match) string {
+	home, away := teamName(match.HomeTeam), teamName(match.AwayTeam)
+
+	switch football.Status(match.Status) {
+	case football.StatusFinished:
+		if match.Score != nil {
+			return fmt.Sprintf("%s %d-%d %s", home, match.Score.FullTime.HomeTeam, match.Score.FullTime.AwayTeam, away)
+		}
+	case football.StatusPostPoned:
+		return fmt.Sprintf("%s vs %s (postponed)", home, away)
+	case football.StatusCanceled:
+		return fmt.Sprintf("%s vs %s (canceled)", home, away)
+	}
+
+	return fmt.Sprintf("%s vs %s", home, away)
+}
+
+func description(match football.Match) string {
+	lines := []string{}
+
+	if match.Competition != nil && match.Competition.Name != "" {
+		lines = append(lines, match.Competition.Name)
+	}
+	if match.Venue != "" {
+		lines = append(lines, "Venue: "+match.Venue)
+	}
+	if match.Stage != "" {
+		lines = append(lines, "Stage: "+match.Stage)
+	}
+	if match.Group != "" {
+		lines = append(lines, "Group: "+match.Group)
+	}
+	if match.Matchday != 0 {
+		lines = append(lines, "Matchday: "+strconv.Itoa(match.Matchday))
+	}
+	if match.Status != "" {
+		lines = append(lines, "Status: "+match.Status)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// status maps the match status to the event status. Postponed and
+// suspended matches have no firm date any more.
+func status(match football.Match) string {
+	switch football.Status(match.Status) {
+	case football.StatusCanceled:
+		return "CANCELLED"
+	case football.StatusPostPoned, football.StatusSuspended:
+		return "TENTATIVE"
+	default:
+		return "CONFIRMED"
+	}
+}
+
+func teamName(team *football.Team) string {
+	if team == nil || team.Name == "" {
+		return "TBD"
+	}
+	return team.Name
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// writer writes content lines, folded at 75 octets and terminated by CRLF.
+type writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (w *writer) line(s string) {
+	if w.err != nil {
+		return
+	}
+
+	// Continuation lines start with a space, which counts towards their
+	// length.
+	limit := lineLength
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && s[cut]&0xC0 == 0x80 { // Never split a UTF-8 sequence.
+			cut--
+		}
+
+		if _, w.err = w.w.WriteString(s[:cut] + "\r\n "); w.err != nil {
+			return
+		}
+
+		s = s[cut:]
+		limit = lineLength - 1
+	}
+
+	_, w.err = w.w.WriteString(s + "\r\n")
+}
@@ -0,0 +1,139 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func testMatch() football.Match {
+	return football.Match{
+		ID:          204950,
+		Competition: &football.Competition{ID: 2013, Name: "Série A"},
+		UtcDate:     "2018-08-13T23:00:00Z",
+		Status:      string(football.StatusScheduled),
+		Venue:       "Estadio Jornalista Mário Filho",
+		Matchday:    18,
+		Stage:       "REGULAR_SEASON",
+		LastUpdated: "2018-08-10T20:01:26Z",
+		HomeTeam:    &football.Team{ID: 1765, Name: "Fluminense FC"},
+		AwayTeam:    &football.Team{ID: 6684, Name: "SC Internacional"},
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	out, err := Marshal([]football.Match{testMatch()}, &Options{Name: "Fluminense, matches"})
+
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//football-data-sdk//ical//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		`X-WR-CALNAME:Fluminense\, matches`,
+		"BEGIN:VEVENT",
+		"UID:match-204950@football-data.org",
+		"DTSTAMP:20180810T200126Z",
+		"LAST-MODIFIED:20180810T200126Z",
+		"SEQUENCE:587246486",
+		"DTSTART:20180813T230000Z",
+		"DTEND:20180814T010000Z",
+		"SUMMARY:Fluminense FC vs SC Internacional",
+		"LOCATION:Estadio Jornalista Mário Filho",
+		`DESCRIPTION:Série A\nVenue: Estadio Jornalista Mário Filho\nStage: REGULA`,
+		` R_SEASON\nMatchday: 18\nStatus: SCHEDULED`,
+		"STATUS:CONFIRMED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, string(out))
+}
+
+func TestMarshal_UpdatesEvents(t *testing.T) {
+	tests := []struct {
+		status   football.Status
+		expected string
+		summary  string
+	}{
+		{football.StatusPostPoned, "STATUS:TENTATIVE", "SUMMARY:Fluminense FC vs SC Internacional (postponed)"},
+		{football.StatusCanceled, "STATUS:CANCELLED", "SUMMARY:Fluminense FC vs SC Internacional (canceled)"},
+		{football.StatusFinished, "STATUS:CONFIRMED", "SUMMARY:Fluminense FC 0-3 SC Internacional"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.status), func(t *testing.T) {
+			match := testMatch()
+			match.Status = string(test.status)
+			match.Score = &football.Score{FullTime: football.Time{HomeTeam: 0, AwayTeam: 3}}
+			match.LastUpdated = "2018-08-12T20:01:26Z"
+
+			out, err := Marshal([]football.Match{match}, nil)
+
+			assert.Nil(t, err)
+			assert.Contains(t, string(out), test.expected+"\r\n")
+			assert.Contains(t, string(out), test.summary+"\r\n")
+			assert.Contains(t, string(out), "UID:match-204950@football-data.org\r\n")
+			// Later LastUpdated, higher sequence.
+			assert.Contains(t, string(out), "SEQUENCE:587419286\r\n")
+		})
+	}
+}
+
+func TestMarshal_Rescheduled(t *testing.T) {
+	match := testMatch()
+	match.UtcDate = "2018-08-20T19:00:00Z"
+	match.Venue = ""
+
+	out, err := Marshal([]football.Match{match}, &Options{Duration: 105 * time.Minute})
+
+	assert.Nil(t, err)
+	assert.Contains(t, string(out), "DTSTART:20180820T190000Z\r\n")
+	assert.Contains(t, string(out), "DTEND:20180820T204500Z\r\n")
+	assert.NotContains(t, string(out), "LOCATION")
+}
+
+func TestMarshal_SkipsMatchesWithoutKickoff(t *testing.T) {
+	match := testMatch()
+	match.UtcDate = ""
+
+	out, err := Marshal([]football.Match{match}, nil)
+
+	assert.Nil(t, err)
+	assert.NotContains(t, string(out), "BEGIN:VEVENT")
+}
+
+func TestMarshal_FoldsLongLines(t *testing.T) {
+	match := testMatch()
+	match.Venue = strings.Repeat("á", 100)
+
+	out, err := Marshal([]football.Match{match}, nil)
+	assert.Nil(t, err)
+
+	for _, line := range strings.Split(string(out), "\r\n") {
+		assert.True(t, len(line) <= 75, line)
+	}
+	assert.Contains(t, strings.Replace(string(out), "\r\n ", "", -1), "LOCATION:"+match.Venue+"\r\n")
+}
+
+func TestEncodeCompetitionMatches(t *testing.T) {
+	matches := &football.CompetitionMatches{
+		Competition: football.Competition{ID: 2021, Name: "Premier League"},
+		Matches:     []football.Match{{ID: 1, UtcDate: "2021-08-13T19:00:00Z"}},
+	}
+
+	var out bytes.Buffer
+	err := EncodeCompetitionMatches(&out, matches, nil)
+
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), "X-WR-CALNAME:Premier League\r\n")
+	assert.Contains(t, out.String(), "DESCRIPTION:Premier League\r\n")
+	assert.Contains(t, out.String(), "SUMMARY:TBD vs TBD\r\n")
+	assert.Nil(t, matches.Matches[0].Competition)
+}
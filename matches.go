@@ -34,10 +34,10 @@ type Match struct {
 }
 
 type MatchesFiltersOptions struct {
-	DateFrom     string `json:"dateFrom,omitempty"`
-	DateTo       string `json:"dateTo,omitempty"`
-	Status       string `json:"status,omitempty"`
-	Competitions string `json:"competitions,omitempty"`
+	DateFrom     string `url:"dateFrom,omitempty"`
+	DateTo       string `url:"dateTo,omitempty"`
+	Status       string `url:"status,omitempty"`
+	Competitions string `url:"competitions,omitempty"`
 }
 
 type MatchResponse struct {
@@ -63,7 +63,7 @@ func (s *MatchService) Find(ctx context.Context, id string) (*MatchResponse, err
 
 	match := &MatchResponse{}
 
-	_, err := s.client.Get(fmt.Sprintf("matches/%s", id), nil, &match)
+	_, err := s.client.GetContext(ctx, fmt.Sprintf("matches/%s", id), nil, &match)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +76,7 @@ func (s *MatchService) Find(ctx context.Context, id string) (*MatchResponse, err
 func (s *MatchService) List(ctx context.Context, filters *MatchesFiltersOptions) (*MatchesCompetition, error) {
 	matchesCompetition := &MatchesCompetition{}
 
-	_, err := s.client.Get("/matches", filters, &matchesCompetition)
+	_, err := s.client.GetContext(ctx, "matches", filters, &matchesCompetition)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,192 @@
+package football
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Plan is a subscription tier of the API. Each tier includes the
+// competitions of the tiers below it.
+type Plan string
+
+const (
+	PlanTierOne   Plan = "TIER_ONE"
+	PlanTierTwo   Plan = "TIER_TWO"
+	PlanTierThree Plan = "TIER_THREE"
+	PlanTierFour  Plan = "TIER_FOUR"
+)
+
+var planRanks = map[Plan]int{
+	PlanTierOne:   1,
+	PlanTierTwo:   2,
+	PlanTierThree: 3,
+	PlanTierFour:  4,
+}
+
+// Includes tells whether a subscription to p gives access to competitions
+// of plan other. Unknown plans are assumed accessible.
+func (p Plan) Includes(other Plan) bool {
+	rank, ok := planRanks[p]
+	otherRank, otherOk := planRanks[other]
+	if !ok || !otherOk {
+		return true
+	}
+
+	return otherRank <= rank
+}
+
+// KnownPlans maps the codes and IDs of the competitions of the free tier
+// to their plan, so they are checked without a request. Plans of other
+// competitions are learned from CompetitionService.List, which the client
+// calls once on its own when it meets a competition it does not know.
+var KnownPlans = map[string]Plan{
+	"WC": PlanTierOne, "2000": PlanTierOne,
+	"CL": PlanTierOne, "2001": PlanTierOne,
+	"BL1": PlanTierOne, "2002": PlanTierOne,
+	"DED": PlanTierOne, "2003": PlanTierOne,
+	"BSA": PlanTierOne, "2013": PlanTierOne,
+	"PD": PlanTierOne, "2014": PlanTierOne,
+	"FL1": PlanTierOne, "2015": PlanTierOne,
+	"ELC": PlanTierOne, "2016": PlanTierOne,
+	"PPL": PlanTierOne, "2017": PlanTierOne,
+	"EC": PlanTierOne, "2018": PlanTierOne,
+	"SA": PlanTierOne, "2019": PlanTierOne,
+	"PL": PlanTierOne, "2021": PlanTierOne,
+	"CLI": PlanTierOne, "2152": PlanTierOne,
+}
+
+// ErrPlanRestricted is matched by errors.Is for every PlanRestrictedError.
+var ErrPlanRestricted = errors.New("Competition is not available in your plan")
+
+// PlanRestrictedError is returned, without a request being made, for
+// competitions outside the plan of the client. It is also returned for
+// the 403 responses the API sends for competitions outside the
+// subscription, with Response set.
+type PlanRestrictedError struct {
+	Competition string
+	// Required is the plan of the competition, empty when unknown.
+	Required Plan
+	Plan     Plan
+	// Response is the API response, when the API refused the request.
+	Response *ErrorResponse
+}
+
+func (e *PlanRestrictedError) Error() string {
+	if e.Required == "" {
+		return fmt.Sprintf("Competition %s is not available in your plan", e.Competition)
+	}
+	return fmt.Sprintf("Competition %s requires %s, your plan is %s", e.Competition, e.Required, e.Plan)
+}
+
+// Is makes errors.Is(err, ErrPlanRestricted) true.
+func (e *PlanRestrictedError) Is(target error) bool {
+	return target == ErrPlanRestricted
+}
+
+// Unwrap returns the API response, if any.
+func (e *PlanRestrictedError) Unwrap() error {
+	if e.Response == nil {
+		return nil
+	}
+	return e.Response
+}
+
+// planTable holds the plans learned from the API.
+type planTable struct {
+	mu    sync.RWMutex
+	plans map[string]Plan
+	// listed is set once the whole competition list was learned.
+	listed bool
+}
+
+func (t *planTable) learn(competitions []Competition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.plans == nil {
+		t.plans = map[string]Plan{}
+	}
+
+	for _, competition := range competitions {
+		if competition.Plan == "" {
+			continue
+		}
+		t.plans[strconv.Itoa(competition.ID)] = Plan(competition.Plan)
+		if competition.Code != "" {
+			t.plans[competition.Code] = Plan(competition.Plan)
+		}
+	}
+}
+
+func (t *planTable) setListed() {
+	t.mu.Lock()
+	t.listed = true
+	t.mu.Unlock()
+}
+
+// lookup returns the plan of the competition id, and whether the whole
+// competition list was learned.
+func (t *planTable) lookup(id string) (plan Plan, ok bool, listed bool) {
+	t.mu.RLock()
+	plan, ok = t.plans[id]
+	listed = t.listed
+	t.mu.RUnlock()
+
+	if !ok {
+		plan, ok = KnownPlans[id]
+	}
+	return plan, ok, listed
+}
+
+// checkPlan fails with a *PlanRestrictedError when the client plan is set
+// and does not include the competition id. Competitions it does not know
+// yet are looked up with CompetitionService.List, once; if that fails the
+// request is let through.
+func (c *Client) checkPlan(ctx context.Context, id string) error {
+	if c.Plan == "" {
+		return nil
+	}
+
+	required, ok, listed := c.plans.lookup(id)
+	if !ok && !listed {
+		if _, err := c.Competitions.List(ctx, nil); err == nil {
+			c.plans.setListed()
+			required, ok, _ = c.plans.lookup(id)
+		}
+	}
+
+	if !ok || c.Plan.Includes(required) {
+		return nil
+	}
+
+	return &PlanRestrictedError{Competition: id, Required: required, Plan: c.Plan}
+}
+
+// planError turns the 403 the API sends for a competition outside the
+// subscription into a *PlanRestrictedError. Other errors are returned as
+// they are.
+func (c *Client) planError(id string, err error) error {
+	response, ok := err.(*ErrorResponse)
+	if !ok || response.StatusCode != http.StatusForbidden {
+		return err
+	}
+
+	body := struct {
+		Message string `json:"message"`
+	}{}
+	json.Unmarshal(response.Body, &body)
+
+	message := strings.ToLower(body.Message)
+	if !strings.Contains(message, "restricted") && !strings.Contains(message, "subscription") {
+		return err
+	}
+
+	required, _, _ := c.plans.lookup(id)
+	return &PlanRestrictedError{Competition: id, Required: required, Plan: c.Plan, Response: response}
+}
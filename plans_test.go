@@ -0,0 +1,150 @@
+package football
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlan_Includes(t *testing.T) {
+	assert.True(t, PlanTierOne.Includes(PlanTierOne))
+	assert.False(t, PlanTierOne.Includes(PlanTierTwo))
+	assert.True(t, PlanTierFour.Includes(PlanTierTwo))
+	assert.True(t, PlanTierTwo.Includes(""))
+}
+
+func TestCompetitionService_ListFiltersByPlan(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/competitions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 3, "competitions": [
+			{"id": 2021, "code": "PL", "plan": "TIER_ONE"},
+			{"id": 2024, "code": "ASL", "plan": "TIER_TWO"},
+			{"id": 2119, "code": "J1", "plan": "TIER_FOUR"}
+		]}`)
+	})
+
+	client := NewClient(httpClient)
+	client.Plan = PlanTierTwo
+
+	list, err := client.Competitions.List(context.Background(), nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, list.Count)
+	assert.Equal(t, "PL", list.Competitions[0].Code)
+	assert.Equal(t, "ASL", list.Competitions[1].Code)
+
+	// The plans seen are used to fail fast.
+	_, err = client.Competitions.Standings(context.Background(), "J1", nil)
+	assert.True(t, errors.Is(err, ErrPlanRestricted))
+	assert.Equal(t, "Competition J1 requires TIER_FOUR, your plan is TIER_TWO", err.Error())
+
+	_, err = client.Competitions.Matches(context.Background(), "2119", nil)
+	restricted, ok := err.(*PlanRestrictedError)
+	assert.True(t, ok)
+	assert.Equal(t, PlanTierFour, restricted.Required)
+}
+
+func TestCompetitionService_WithoutPlan(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/competitions/2119", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	client := NewClient(httpClient)
+	client.plans.learn([]Competition{{ID: 2119, Code: "J1", Plan: "TIER_FOUR"}})
+
+	_, err := client.Competitions.Find(context.Background(), "2119")
+
+	assert.False(t, errors.Is(err, ErrPlanRestricted))
+	assert.True(t, ErrorContains(err, "403"))
+}
+
+func TestCompetitionService_KnownPlans(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	lists := 0
+	mux.HandleFunc("/v2/competitions", func(w http.ResponseWriter, r *http.Request) {
+		lists++
+		fmt.Fprint(w, `{"count": 1, "competitions": [{"id": 2119, "code": "J1", "plan": "TIER_FOUR"}]}`)
+	})
+
+	client := NewClient(httpClient)
+	client.Plan = PlanTierOne
+
+	assert.Nil(t, client.checkPlan(context.Background(), "PL"))
+	assert.Equal(t, 0, lists)
+
+	KnownPlans["TEST"] = PlanTierThree
+	defer delete(KnownPlans, "TEST")
+
+	assert.True(t, errors.Is(client.checkPlan(context.Background(), "TEST"), ErrPlanRestricted))
+	assert.Equal(t, 0, lists)
+
+	// Unknown competitions are learned from the competition list, once.
+	assert.True(t, errors.Is(client.checkPlan(context.Background(), "J1"), ErrPlanRestricted))
+	assert.Nil(t, client.checkPlan(context.Background(), "9999"))
+	assert.Equal(t, 1, lists)
+}
+
+func TestCompetitionService_PlanLearnedLazily(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/competitions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 1, "competitions": [{"id": 2119, "code": "J1", "plan": "TIER_FOUR"}]}`)
+	})
+	mux.HandleFunc("/v2/competitions/J1/standings", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("restricted competition requested")
+	})
+
+	client := NewClient(httpClient)
+	client.Plan = PlanTierTwo
+
+	_, err := client.Competitions.Standings(context.Background(), "J1", nil)
+
+	assert.Equal(t, "Competition J1 requires TIER_FOUR, your plan is TIER_TWO", err.Error())
+}
+
+func TestCompetitionService_PlanForbidden(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/competitions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/v2/competitions/2119", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "The resource you are looking for is restricted. Please pass a valid API token and check your subscription for permission.", "errorCode": 403}`)
+	})
+	mux.HandleFunc("/v2/competitions/2120", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "Forbidden", "errorCode": 403}`)
+	})
+
+	client := NewClient(httpClient)
+	client.Plan = PlanTierOne
+
+	_, err := client.Competitions.Find(context.Background(), "2119")
+
+	restricted, ok := err.(*PlanRestrictedError)
+	assert.True(t, ok)
+	assert.True(t, errors.Is(err, ErrPlanRestricted))
+	assert.Equal(t, "Competition 2119 is not available in your plan", err.Error())
+
+	var response *ErrorResponse
+	assert.True(t, errors.As(err, &response))
+	assert.Equal(t, restricted.Response, response)
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	_, err = client.Competitions.Find(context.Background(), "2120")
+	assert.False(t, errors.Is(err, ErrPlanRestricted))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // PlayerService provides methods for accessing information
@@ -26,11 +28,18 @@ type Player struct {
 }
 
 type PlayerFiltersOptions struct {
-	DateFrom     string `json:"dateFrom,omitempty"`
-	DateTo       string `json:"dateTo,omitempty"`
-	Status       string `json:"status,omitempty"`
-	Competitions string `json:"competitions,omitempty"`
-	Limit        int64  `json:"limit,omitempty"`
+	DateFrom     string `url:"dateFrom,omitempty"`
+	DateTo       string `url:"dateTo,omitempty"`
+	Status       string `url:"status,omitempty"`
+	Competitions string `url:"competitions,omitempty"`
+	Limit        int64  `url:"limit,omitempty"`
+}
+
+type PlayerStatsFiltersOptions struct {
+	DateFrom     string
+	DateTo       string
+	Competitions string // Comma separated competition IDs.
+	Season       string // Starting year of the season, e.g. "2021".
 }
 
 type PlayerMatches struct {
@@ -50,7 +59,7 @@ func (s *PlayerService) Find(ctx context.Context, id string) (*Player, error) {
 
 	player := &Player{}
 
-	_, err := s.client.Get(fmt.Sprintf("players/%s", id), nil, &player)
+	_, err := s.client.GetContext(ctx, fmt.Sprintf("players/%s", id), nil, &player)
 	if err != nil {
 		return nil, err
 	}
@@ -68,10 +77,55 @@ func (s *PlayerService) Matches(ctx context.Context, id string, filters *PlayerF
 
 	playerMatches := &PlayerMatches{}
 
-	_, err := s.client.Get(fmt.Sprintf("players/%s/matches", id), filters, &playerMatches)
+	_, err := s.client.GetContext(ctx, fmt.Sprintf("players/%s/matches", id), filters, &playerMatches)
 	if err != nil {
 		return nil, err
 	}
 
 	return playerMatches, nil
 }
+
+// Stats takes a Player ID and returns the statistics of that Player over
+// the finished matches accepted by filters. Matches returned without
+// lineups are fetched again with MatchService.Find, which costs one
+// request per match.
+// https://www.football-data.org/documentation/api
+func (s *PlayerService) Stats(ctx context.Context, id string, filters *PlayerStatsFiltersOptions) (*PlayerStats, error) {
+	if filters == nil {
+		filters = &PlayerStatsFiltersOptions{}
+	}
+
+	playerMatches, err := s.Matches(ctx, id, &PlayerFiltersOptions{
+		DateFrom:     filters.DateFrom,
+		DateTo:       filters.DateTo,
+		Status:       string(StatusFinished),
+		Competitions: filters.Competitions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []Match{}
+	for _, match := range playerMatches.Matches {
+		if len(filters.Season) > 0 && (match.Season == nil || !strings.HasPrefix(match.Season.StartDate, filters.Season)) {
+			continue
+		}
+
+		if match.HomeTeam == nil || match.HomeTeam.Lineup == nil {
+			unfolded, err := s.client.Matches.Find(ctx, strconv.Itoa(match.ID))
+			if err != nil {
+				return nil, err
+			}
+			match = unfolded.Match
+		}
+
+		matches = append(matches, match)
+	}
+
+	player := playerMatches.Player
+	if player.ID == 0 {
+		player.ID, _ = strconv.ParseInt(id, 10, 64)
+	}
+
+	return AggregatePlayerStats(player, matches), nil
+}
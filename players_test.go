@@ -206,3 +206,107 @@ func TestPlayerService_Matches(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expected, list)
 }
+
+func TestPlayerService_Stats(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/players/3/matches", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assert.Equal(t, "2013", r.URL.Query().Get("competitions"))
+		assert.Equal(t, "FINISHED", r.URL.Query().Get("status"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{
+			"count": 2,
+			"player": {
+				"id": 3,
+				"name": "Jádson"
+			},
+			"matches": [
+				{
+					"id": 1,
+					"season": {
+						"startDate": "2020-08-08"
+					},
+					"status": "FINISHED"
+				},
+				{
+					"id": 2,
+					"season": {
+						"startDate": "2021-05-29"
+					},
+					"status": "FINISHED"
+				}
+			]
+		}`)
+	})
+
+	mux.HandleFunc("/v2/matches/2", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{
+			"match": {
+				"id": 2,
+				"status": "FINISHED",
+				"score": {
+					"duration": "REGULAR"
+				},
+				"homeTeam": {
+					"id": 1765,
+					"lineup": [
+						{
+							"id": 3,
+							"name": "Jádson"
+						}
+					]
+				},
+				"awayTeam": {
+					"id": 6684,
+					"lineup": []
+				},
+				"goals": [
+					{
+						"minute": 12,
+						"team": {
+							"id": 1765
+						},
+						"scorer": {
+							"id": 3
+						}
+					}
+				],
+				"substitutions": [
+					{
+						"minute": 45,
+						"team": {
+							"id": 1765
+						},
+						"playerOut": {
+							"id": 3
+						},
+						"playerIn": {
+							"id": 4
+						}
+					}
+				]
+			}
+		}`)
+	})
+
+	expected := &PlayerStats{
+		Player:      Player{ID: 3, Name: "Jádson"},
+		Matches:     1,
+		Appearances: 1,
+		Starts:      1,
+		Minutes:     45,
+		Goals:       1,
+	}
+
+	ctx := context.Background()
+	client := NewClient(httpClient)
+	stats, err := client.Players.Stats(ctx, "3", &PlayerStatsFiltersOptions{Competitions: "2013", Season: "2021"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, stats)
+	assert.Equal(t, 2.0, stats.GoalsPer90())
+}
@@ -0,0 +1,77 @@
+package poisson
+
+// ScoreMatrix holds the probability of each score line of a match.
+// Probabilities[h][a] is the probability of the home team scoring h goals
+// and the away team scoring a goals.
+type ScoreMatrix struct {
+	HomeExpected  float64     `json:"homeExpected"`
+	AwayExpected  float64     `json:"awayExpected"`
+	Probabilities [][]float64 `json:"probabilities"`
+}
+
+// HomeWin returns the probability of a home win.
+func (s *ScoreMatrix) HomeWin() float64 {
+	return s.sum(func(h, a int) bool { return h > a })
+}
+
+// Draw returns the probability of a draw.
+func (s *ScoreMatrix) Draw() float64 {
+	return s.sum(func(h, a int) bool { return h == a })
+}
+
+// AwayWin returns the probability of an away win.
+func (s *ScoreMatrix) AwayWin() float64 {
+	return s.sum(func(h, a int) bool { return h < a })
+}
+
+// Over returns the probability of more than line total goals, e.g. 2.5.
+func (s *ScoreMatrix) Over(line float64) float64 {
+	return s.sum(func(h, a int) bool { return float64(h+a) > line })
+}
+
+// Under returns the probability of fewer than line total goals.
+func (s *ScoreMatrix) Under(line float64) float64 {
+	return s.sum(func(h, a int) bool { return float64(h+a) < line })
+}
+
+// BothTeamsToScore returns the probability of both teams scoring.
+func (s *ScoreMatrix) BothTeamsToScore() float64 {
+	return s.sum(func(h, a int) bool { return h > 0 && a > 0 })
+}
+
+// Probability returns the probability of an exact score line.
+func (s *ScoreMatrix) Probability(home, away int) float64 {
+	if home < 0 || away < 0 || home >= len(s.Probabilities) || away >= len(s.Probabilities[home]) {
+		return 0
+	}
+	return s.Probabilities[home][away]
+}
+
+// MostLikely returns the most likely score line.
+func (s *ScoreMatrix) MostLikely() (int, int) {
+	home, away, best := 0, 0, -1.0
+	for h, row := range s.Probabilities {
+		for a, p := range row {
+			if p > best {
+				home, away, best = h, a, p
+			}
+		}
+	}
+	return home, away
+}
+
+func (s *ScoreMatrix) total() float64 {
+	return s.sum(func(h, a int) bool { return true })
+}
+
+func (s *ScoreMatrix) sum(include func(h, a int) bool) float64 {
+	total := 0.0
+	for h, row := range s.Probabilities {
+		for a, p := range row {
+			if include(h, a) {
+				total += p
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,326 @@
+// Package poisson fits independent Poisson and Dixon-Coles goal models on
+// match results and predicts score-line probabilities.
+//
+// The goals of the home team are Poisson distributed with mean
+// HomeAdvantage * Attack[home] * Defence[away], and those of the away team
+// with mean Attack[away] * Defence[home].
+package poisson
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+const (
+	defaultMaxGoals   = 10
+	defaultIterations = 100
+)
+
+// Options controls how a model is fitted.
+type Options struct {
+	DixonColes bool          // Correct the probabilities of low scores with the Dixon-Coles rho.
+	HalfLife   time.Duration // Matches lose half of their weight every HalfLife; zero disables decay.
+	Reference  time.Time     // Time the decay is measured from, defaults to the latest match.
+	MaxGoals   int           // Largest number of goals per team in score matrices, defaults to 10.
+	Iterations int           // Fitting iterations, defaults to 100.
+}
+
+// Model holds fitted team strengths.
+type Model struct {
+	Attack        map[int]float64 `json:"attack"`  // Attack strength by team ID, averaging 1.
+	Defence       map[int]float64 `json:"defence"` // Defence weakness by team ID.
+	HomeAdvantage float64         `json:"homeAdvantage"`
+	Rho           float64         `json:"rho"` // Dixon-Coles dependence, zero for independent goals.
+	MaxGoals      int             `json:"maxGoals"`
+}
+
+type observation struct {
+	home, away           int
+	homeGoals, awayGoals int
+	weight               float64
+}
+
+// Fit estimates a model from the finished matches by maximum likelihood.
+func Fit(matches []football.Match, opts *Options) (*Model, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	observations, err := observe(matches, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(observations) == 0 {
+		return nil, errors.New("At least one finished match is required")
+	}
+
+	m := &Model{
+		Attack:        map[int]float64{},
+		Defence:       map[int]float64{},
+		HomeAdvantage: 1,
+		MaxGoals:      opts.MaxGoals,
+	}
+	if m.MaxGoals <= 0 {
+		m.MaxGoals = defaultMaxGoals
+	}
+	for _, o := range observations {
+		m.Attack[o.home], m.Attack[o.away] = 1, 1
+		m.Defence[o.home], m.Defence[o.away] = 1, 1
+	}
+
+	iterations := opts.Iterations
+	if iterations <= 0 {
+		iterations = defaultIterations
+	}
+	for i := 0; i < iterations; i++ {
+		m.step(observations)
+	}
+
+	if opts.DixonColes {
+		m.Rho = m.fitRho(observations)
+	}
+
+	return m, nil
+}
+
+// observe turns finished matches into weighted observations.
+func observe(matches []football.Match, opts *Options) ([]observation, error) {
+	dates := []time.Time{}
+	finished := []football.Match{}
+	reference := opts.Reference
+
+	for _, match := range matches {
+		if match.Status != string(football.StatusFinished) || match.Score == nil || match.HomeTeam == nil || match.AwayTeam == nil {
+			continue
+		}
+
+		date, err := time.Parse(time.RFC3339, match.UtcDate)
+		if err != nil && opts.HalfLife > 0 {
+			return nil, err
+		}
+		if opts.Reference.IsZero() && date.After(reference) {
+			reference = date
+		}
+
+		dates = append(dates, date)
+		finished = append(finished, match)
+	}
+
+	observations := make([]observation, len(finished))
+	for i, match := range finished {
+		weight := 1.0
+		if opts.HalfLife > 0 {
+			age := reference.Sub(dates[i])
+			weight = math.Pow(0.5, float64(age)/float64(opts.HalfLife))
+		}
+
+		// Goals of a penalty shoot-out are not part of the score line.
+		observations[i] = observation{
+			home:      match.HomeTeam.ID,
+			away:      match.AwayTeam.ID,
+			homeGoals: match.Score.FullTime.HomeTeam - match.Score.Penalties.HomeTeam,
+			awayGoals: match.Score.FullTime.AwayTeam - match.Score.Penalties.AwayTeam,
+			weight:    weight,
+		}
+	}
+
+	return observations, nil
+}
+
+// step runs one round of the fixed-point equations of the likelihood and
+// rescales attacks to average 1.
+func (m *Model) step(observations []observation) {
+	scored, expected := map[int]float64{}, map[int]float64{}
+	for _, o := range observations {
+		scored[o.home] += o.weight * float64(o.homeGoals)
+		scored[o.away] += o.weight * float64(o.awayGoals)
+		expected[o.home] += o.weight * m.HomeAdvantage * m.Defence[o.away]
+		expected[o.away] += o.weight * m.Defence[o.home]
+	}
+	for team := range m.Attack {
+		if expected[team] > 0 {
+			m.Attack[team] = scored[team] / expected[team]
+		}
+	}
+
+	conceded, expected := map[int]float64{}, map[int]float64{}
+	for _, o := range observations {
+		conceded[o.away] += o.weight * float64(o.homeGoals)
+		conceded[o.home] += o.weight * float64(o.awayGoals)
+		expected[o.away] += o.weight * m.HomeAdvantage * m.Attack[o.home]
+		expected[o.home] += o.weight * m.Attack[o.away]
+	}
+	for team := range m.Defence {
+		if expected[team] > 0 {
+			m.Defence[team] = conceded[team] / expected[team]
+		}
+	}
+
+	homeGoals, homeExpected := 0.0, 0.0
+	for _, o := range observations {
+		homeGoals += o.weight * float64(o.homeGoals)
+		homeExpected += o.weight * m.Attack[o.home] * m.Defence[o.away]
+	}
+	if homeExpected > 0 {
+		m.HomeAdvantage = homeGoals / homeExpected
+	}
+
+	mean := 0.0
+	for _, attack := range m.Attack {
+		mean += attack
+	}
+	mean /= float64(len(m.Attack))
+	if mean > 0 {
+		for team := range m.Attack {
+			m.Attack[team] /= mean
+			m.Defence[team] *= mean
+		}
+	}
+}
+
+// fitRho finds the rho maximising the likelihood of the low scores, with
+// the team strengths held fixed.
+func (m *Model) fitRho(observations []observation) float64 {
+	likelihood := func(rho float64) float64 {
+		total := 0.0
+		for _, o := range observations {
+			home, away := m.ExpectedGoals(o.home, o.away)
+			tau := correction(o.homeGoals, o.awayGoals, home, away, rho)
+			if tau <= 0 {
+				return math.Inf(-1)
+			}
+			total += o.weight * math.Log(tau)
+		}
+		return total
+	}
+
+	// Golden-section search over the usual range of rho.
+	low, high := -0.3, 0.3
+	ratio := (math.Sqrt(5) - 1) / 2
+	for i := 0; i < 60; i++ {
+		a := high - ratio*(high-low)
+		b := low + ratio*(high-low)
+		if likelihood(a) < likelihood(b) {
+			low = a
+		} else {
+			high = b
+		}
+	}
+
+	return (low + high) / 2
+}
+
+// correction is the Dixon-Coles adjustment of the probability of a score.
+func correction(homeGoals, awayGoals int, home, away, rho float64) float64 {
+	switch {
+	case homeGoals == 0 && awayGoals == 0:
+		return 1 - home*away*rho
+	case homeGoals == 0 && awayGoals == 1:
+		return 1 + home*rho
+	case homeGoals == 1 && awayGoals == 0:
+		return 1 + away*rho
+	case homeGoals == 1 && awayGoals == 1:
+		return 1 - rho
+	default:
+		return 1
+	}
+}
+
+// ExpectedGoals returns the expected goals of each team. Teams the model
+// was not fitted on are treated as average.
+func (m *Model) ExpectedGoals(home, away int) (float64, float64) {
+	return m.HomeAdvantage * strength(m.Attack, home) * strength(m.Defence, away),
+		strength(m.Attack, away) * strength(m.Defence, home)
+}
+
+// Predict returns the score matrix of a match between home and away.
+func (m *Model) Predict(home, away int) *ScoreMatrix {
+	homeMean, awayMean := m.ExpectedGoals(home, away)
+	maxGoals := m.MaxGoals
+	if maxGoals <= 0 {
+		maxGoals = defaultMaxGoals
+	}
+
+	matrix := &ScoreMatrix{
+		HomeExpected:  homeMean,
+		AwayExpected:  awayMean,
+		Probabilities: make([][]float64, maxGoals+1),
+	}
+
+	homeProbabilities := distribution(homeMean, maxGoals)
+	awayProbabilities := distribution(awayMean, maxGoals)
+	for i := range matrix.Probabilities {
+		matrix.Probabilities[i] = make([]float64, maxGoals+1)
+		for j := range matrix.Probabilities[i] {
+			p := homeProbabilities[i] * awayProbabilities[j]
+			matrix.Probabilities[i][j] = p * correction(i, j, homeMean, awayMean, m.Rho)
+		}
+	}
+
+	return matrix
+}
+
+// Prediction is the score matrix of a scheduled match.
+type Prediction struct {
+	MatchID int          `json:"matchId"`
+	Scores  *ScoreMatrix `json:"scores"`
+}
+
+// PredictScheduled returns the predictions of the scheduled matches,
+// whether or not their kick-off time is confirmed.
+func (m *Model) PredictScheduled(matches []football.Match) []Prediction {
+	predictions := []Prediction{}
+	for _, match := range matches {
+		status := football.Status(match.Status)
+		if status != football.StatusScheduled && status != football.StatusTimed {
+			continue
+		}
+		if match.HomeTeam == nil || match.AwayTeam == nil {
+			continue
+		}
+		predictions = append(predictions, Prediction{
+			MatchID: match.ID,
+			Scores:  m.Predict(match.HomeTeam.ID, match.AwayTeam.ID),
+		})
+	}
+	return predictions
+}
+
+// Score samples a score line, so the model can be used as a
+// simulation.Model.
+func (m *Model) Score(r *rand.Rand, home, away int) (int, int) {
+	matrix := m.Predict(home, away)
+
+	p := r.Float64() * matrix.total()
+	for i, row := range matrix.Probabilities {
+		for j, probability := range row {
+			p -= probability
+			if p <= 0 {
+				return i, j
+			}
+		}
+	}
+
+	return matrix.MostLikely()
+}
+
+func strength(strengths map[int]float64, team int) float64 {
+	if s, ok := strengths[team]; ok {
+		return s
+	}
+	return 1
+}
+
+// distribution returns the Poisson probabilities of 0 to n goals.
+func distribution(mean float64, n int) []float64 {
+	probabilities := make([]float64, n+1)
+	probabilities[0] = math.Exp(-mean)
+	for k := 1; k <= n; k++ {
+		probabilities[k] = probabilities[k-1] * mean / float64(k)
+	}
+	return probabilities
+}
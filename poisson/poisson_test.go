@@ -0,0 +1,130 @@
+package poisson
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/matheustex/football-data-sdk/simulation"
+	"github.com/stretchr/testify/assert"
+)
+
+func match(date string, home, homeGoals, awayGoals, away int) football.Match {
+	return football.Match{
+		UtcDate:  date,
+		Status:   string(football.StatusFinished),
+		HomeTeam: &football.Team{ID: home},
+		AwayTeam: &football.Team{ID: away},
+		Score:    &football.Score{FullTime: football.Time{HomeTeam: homeGoals, AwayTeam: awayGoals}},
+	}
+}
+
+// history is a double round robin where team 1 is strong and team 3 is
+// weak, and home teams score more.
+func history() []football.Match {
+	return []football.Match{
+		match("2021-08-01T15:00:00Z", 1, 3, 0, 2),
+		match("2021-08-08T15:00:00Z", 2, 1, 2, 1),
+		match("2021-08-15T15:00:00Z", 1, 4, 0, 3),
+		match("2021-08-22T15:00:00Z", 3, 1, 2, 1),
+		match("2021-08-29T15:00:00Z", 2, 2, 1, 3),
+		match("2021-09-05T15:00:00Z", 3, 1, 1, 2),
+	}
+}
+
+func TestFit(t *testing.T) {
+	m, err := Fit(history(), nil)
+
+	assert.Nil(t, err)
+	assert.True(t, m.Attack[1] > m.Attack[2])
+	assert.True(t, m.Attack[2] > m.Attack[3])
+	assert.True(t, m.Defence[1] < m.Defence[3])
+	assert.True(t, m.HomeAdvantage > 1)
+	assert.Equal(t, 0.0, m.Rho)
+	assert.InDelta(t, 1.0, (m.Attack[1]+m.Attack[2]+m.Attack[3])/3, 1e-9)
+
+	// The fitted model reproduces the goals observed for each team.
+	expected := 0.0
+	for _, o := range history() {
+		home, _ := m.ExpectedGoals(o.HomeTeam.ID, o.AwayTeam.ID)
+		expected += home
+	}
+	assert.InDelta(t, 12.0, expected, 1e-3)
+}
+
+func TestFit_NoMatches(t *testing.T) {
+	_, err := Fit(nil, nil)
+
+	assert.NotNil(t, err)
+}
+
+func TestFit_HalfLife(t *testing.T) {
+	matches := []football.Match{
+		match("2021-01-01T15:00:00Z", 1, 0, 3, 2),
+		match("2021-08-01T15:00:00Z", 1, 3, 0, 2),
+	}
+
+	flat, _ := Fit(matches, nil)
+	decayed, _ := Fit(matches, &Options{HalfLife: 30 * 24 * time.Hour})
+
+	assert.InDelta(t, flat.Attack[1], flat.Attack[2], 1e-6)
+	assert.True(t, decayed.Attack[1] > decayed.Attack[2])
+}
+
+func TestFit_DixonColes(t *testing.T) {
+	matches := append(history(),
+		match("2021-09-12T15:00:00Z", 1, 0, 0, 2),
+		match("2021-09-19T15:00:00Z", 2, 1, 1, 3),
+		match("2021-09-26T15:00:00Z", 3, 0, 0, 1),
+	)
+
+	m, err := Fit(matches, &Options{DixonColes: true})
+
+	assert.Nil(t, err)
+	assert.NotEqual(t, 0.0, m.Rho)
+	assert.True(t, m.Rho > -0.3 && m.Rho < 0.3)
+}
+
+func TestModel_Predict(t *testing.T) {
+	m, _ := Fit(history(), nil)
+	scores := m.Predict(1, 3)
+
+	assert.InDelta(t, 1.0, scores.HomeWin()+scores.Draw()+scores.AwayWin(), 1e-2)
+	assert.InDelta(t, 1.0, scores.Over(2.5)+scores.Under(2.5), 1e-2)
+	assert.True(t, scores.HomeWin() > scores.AwayWin())
+	assert.True(t, scores.HomeExpected > scores.AwayExpected)
+	assert.True(t, scores.BothTeamsToScore() < 1)
+	assert.Equal(t, 11, len(scores.Probabilities))
+	assert.Equal(t, scores.Probabilities[2][1], scores.Probability(2, 1))
+	assert.Equal(t, 0.0, scores.Probability(20, 0))
+}
+
+func TestModel_PredictScheduled(t *testing.T) {
+	m, _ := Fit(history(), nil)
+
+	predictions := m.PredictScheduled([]football.Match{
+		match("2021-08-01T15:00:00Z", 1, 3, 0, 2),
+		{ID: 7, Status: "SCHEDULED", HomeTeam: &football.Team{ID: 2}, AwayTeam: &football.Team{ID: 1}},
+		{ID: 8, Status: "TIMED", HomeTeam: &football.Team{ID: 3}, AwayTeam: &football.Team{ID: 1}},
+	})
+
+	assert.Len(t, predictions, 2)
+	assert.Equal(t, 7, predictions[0].MatchID)
+	assert.Equal(t, 8, predictions[1].MatchID)
+}
+
+func TestModel_Score(t *testing.T) {
+	m, _ := Fit(history(), nil)
+	var model simulation.Model = m
+
+	r := rand.New(rand.NewSource(1))
+	homeGoals, awayGoals := 0, 0
+	for i := 0; i < 2000; i++ {
+		h, a := model.Score(r, 1, 3)
+		homeGoals += h
+		awayGoals += a
+	}
+
+	assert.True(t, homeGoals > awayGoals)
+}
@@ -0,0 +1,72 @@
+package proxy
+
+import (
+	"sync"
+	"time"
+)
+
+// cache holds response bodies until they expire.
+type cache struct {
+	max int
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+type entry struct {
+	body    []byte
+	expires time.Time
+}
+
+func newCache(max int) *cache {
+	return &cache{max: max, entries: map[string]entry{}}
+}
+
+func (c *cache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return e.body, true
+}
+
+func (c *cache) set(key string, body []byte, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.max {
+		c.evict()
+	}
+
+	c.entries[key] = entry{body: body, expires: expires}
+}
+
+// evict removes the expired entries, or the one closest to expiry when
+// none is.
+func (c *cache) evict() {
+	now := time.Now()
+	oldest, oldestKey := time.Time{}, ""
+
+	for key, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestKey == "" || e.expires.Before(oldest) {
+			oldest, oldestKey = e.expires, key
+		}
+	}
+
+	if len(c.entries) >= c.max && oldestKey != "" {
+		delete(c.entries, oldestKey)
+	}
+}
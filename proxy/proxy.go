@@ -0,0 +1,302 @@
+// Package proxy serves the football-data.org REST paths from a shared
+// cache, so several services can share one token and one quota.
+//
+// Identical concurrent requests are collapsed into one upstream call,
+// upstream calls are queued against the client rate limiter, and usage is
+// counted per consumer.
+package proxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// Options configures a Proxy. Zero fields take the defaults.
+type Options struct {
+	// TTL is how long responses are cached. Defaults to a minute.
+	TTL time.Duration
+	// TTLs overrides TTL for paths starting with a prefix, such as
+	// "areas" or "competitions/PL/matches". The longest prefix wins.
+	TTLs map[string]time.Duration
+	// MaxEntries bounds the cache; the entries closest to expiry are
+	// evicted first. Defaults to 10000.
+	MaxEntries int
+	// Consumers maps the X-Auth-Token sent by consumers to their names.
+	// When set, requests with other tokens are rejected. When empty,
+	// consumers are named after a hash of their token, or their address.
+	Consumers map[string]string
+	// AdminToken, sent as X-Auth-Token, gives access to /stats. Without
+	// it, /stats is only served to configured Consumers.
+	AdminToken string
+	// Timeout bounds an upstream call, including the time queued for the
+	// rate limiter. Defaults to 2 minutes.
+	Timeout time.Duration
+}
+
+// Usage counts the requests of a consumer.
+type Usage struct {
+	Requests int `json:"requests"`
+	// CacheHits were served from the cache.
+	CacheHits int `json:"cacheHits"`
+	// Collapsed shared the upstream call of an identical request.
+	Collapsed int `json:"collapsed"`
+	// Upstream made an upstream call.
+	Upstream int `json:"upstream"`
+	// Errors got an error response.
+	Errors   int       `json:"errors"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// Proxy is an http.Handler serving API paths, such as
+// /v2/competitions/PL/standings, through the client. GET /stats returns
+// the usage per consumer, to the admin and configured consumers.
+type Proxy struct {
+	Client  *football.Client
+	Options Options
+
+	cache *cache
+
+	mu       sync.Mutex
+	inflight map[string]*call
+	usage    map[string]*Usage
+}
+
+// call is an upstream request shared by identical requests.
+type call struct {
+	done   chan struct{}
+	body   []byte
+	status int
+	err    error
+}
+
+// New returns a proxy reading through client, which should have a
+// RateLimiter matching the quota of its token.
+func New(client *football.Client, opts *Options) *Proxy {
+	options := Options{}
+	if opts != nil {
+		options = *opts
+	}
+
+	if options.TTL == 0 {
+		options.TTL = time.Minute
+	}
+	if options.MaxEntries == 0 {
+		options.MaxEntries = 10000
+	}
+	if options.Timeout == 0 {
+		options.Timeout = 2 * time.Minute
+	}
+
+	return &Proxy{
+		Client:   client,
+		Options:  options,
+		cache:    newCache(options.MaxEntries),
+		inflight: map[string]*call{},
+		usage:    map[string]*Usage{},
+	}
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if r.URL.Path == "/stats" {
+		p.serveStats(w, r)
+		return
+	}
+
+	consumer, ok := p.consumer(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unknown consumer token")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/"+football.APIVersion)
+	path = strings.Trim(path, "/")
+	if path == "" {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	query := r.URL.Query()
+	key := path
+	if encoded := query.Encode(); encoded != "" {
+		key += "?" + encoded
+	}
+
+	if body, ok := p.cache.get(key); ok {
+		p.count(consumer, func(u *Usage) { u.CacheHits++ })
+		write(w, "HIT", http.StatusOK, body)
+		return
+	}
+
+	p.mu.Lock()
+	c, shared := p.inflight[key]
+	if !shared {
+		c = &call{done: make(chan struct{})}
+		p.inflight[key] = c
+		go p.fetch(key, path, query, c)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-c.done:
+	case <-r.Context().Done():
+		// The upstream call goes on for the other waiters and the cache.
+		return
+	}
+
+	if shared {
+		p.count(consumer, func(u *Usage) { u.Collapsed++ })
+	} else {
+		p.count(consumer, func(u *Usage) { u.Upstream++ })
+	}
+
+	cacheStatus := "MISS"
+	if shared {
+		cacheStatus = "COLLAPSED"
+	}
+
+	if c.err != nil {
+		p.count(consumer, func(u *Usage) { u.Errors++ })
+		writeError(w, http.StatusBadGateway, c.err.Error())
+		return
+	}
+
+	if c.status != http.StatusOK {
+		p.count(consumer, func(u *Usage) { u.Errors++ })
+	}
+	write(w, cacheStatus, c.status, c.body)
+}
+
+// fetch makes the upstream call of c. It is detached from the requests
+// waiting for it, so one consumer hanging up does not fail the others.
+func (p *Proxy) fetch(key, path string, query url.Values, c *call) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.Options.Timeout)
+	defer cancel()
+
+	body, err := p.Client.GetContext(ctx, path, query, nil)
+	if response, ok := err.(*football.ErrorResponse); ok {
+		c.status, c.body = response.StatusCode, response.Body
+	} else if err != nil {
+		c.err = err
+	} else {
+		c.status, c.body = http.StatusOK, body
+		p.cache.set(key, body, time.Now().Add(p.ttl(path)))
+	}
+
+	p.mu.Lock()
+	delete(p.inflight, key)
+	p.mu.Unlock()
+
+	close(c.done)
+}
+
+// ttl returns the cache lifetime of path.
+func (p *Proxy) ttl(path string) time.Duration {
+	ttl, longest := p.Options.TTL, -1
+	for prefix, d := range p.Options.TTLs {
+		if strings.HasPrefix(path, prefix) && len(prefix) > longest {
+			ttl, longest = d, len(prefix)
+		}
+	}
+	return ttl
+}
+
+// serveStats writes the usage per consumer, to the admin or a configured
+// consumer only.
+func (p *Proxy) serveStats(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("X-Auth-Token")
+
+	admin := p.Options.AdminToken != "" &&
+		subtle.ConstantTimeCompare([]byte(token), []byte(p.Options.AdminToken)) == 1
+	_, consumer := p.Options.Consumers[token]
+
+	if !admin && !consumer {
+		writeError(w, http.StatusForbidden, "Stats require the admin token or a consumer token")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(p.Usage())
+}
+
+// consumer names the consumer of r, and false when it is not allowed.
+func (p *Proxy) consumer(r *http.Request) (string, bool) {
+	token := r.Header.Get("X-Auth-Token")
+
+	if len(p.Options.Consumers) > 0 {
+		name, ok := p.Options.Consumers[token]
+		return name, ok
+	}
+
+	if token != "" {
+		return tokenName(token), true
+	}
+
+	host := r.RemoteAddr
+	if i := strings.LastIndex(host, ":"); i > 0 {
+		host = host[:i]
+	}
+	return host, true
+}
+
+// tokenName names an unknown consumer after a hash of its token, never the
+// token itself, as usage is published on /stats.
+func tokenName(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "token-" + hex.EncodeToString(sum[:4])
+}
+
+func (p *Proxy) count(consumer string, fn func(u *Usage)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	usage, ok := p.usage[consumer]
+	if !ok {
+		usage = &Usage{}
+		p.usage[consumer] = usage
+	}
+
+	usage.Requests++
+	usage.LastSeen = time.Now()
+	fn(usage)
+}
+
+// Usage returns a copy of the usage per consumer.
+func (p *Proxy) Usage() map[string]Usage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	usage := make(map[string]Usage, len(p.usage))
+	for consumer, u := range p.usage {
+		usage[consumer] = *u
+	}
+	return usage
+}
+
+func write(w http.ResponseWriter, cacheStatus string, status int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Cache", cacheStatus)
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"message": message, "errorCode": status})
+}
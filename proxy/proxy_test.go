@@ -0,0 +1,178 @@
+package proxy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func testProxy(t *testing.T, opts *Options) (*Proxy, *int32, chan struct{}) {
+	t.Setenv("FOOTBALL_API_TOKEN", "x")
+
+	var upstream int32
+	release := make(chan struct{})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/competitions/PL/standings", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&upstream, 1)
+		<-release
+		assert.Equal(t, "x", r.Header.Get("X-Auth-Token"))
+		fmt.Fprintf(w, `{"season": {"id": %s}}`, r.URL.Query().Get("season"))
+	})
+	mux.HandleFunc("/v2/areas", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&upstream, 1)
+		fmt.Fprint(w, `{"count": 0}`)
+	})
+	mux.HandleFunc("/v2/teams/0", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&upstream, 1)
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "The resource you are looking for does not exist.", "errorCode": 404}`)
+	})
+
+	api := httptest.NewServer(mux)
+	t.Cleanup(api.Close)
+
+	client := football.NewClient(nil)
+	client.BaseURL, _ = url.Parse(api.URL + "/v2")
+	return New(client, opts), &upstream, release
+}
+
+func get(p *Proxy, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", path, nil)
+	if token != "" {
+		req.Header.Set("X-Auth-Token", token)
+	}
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestProxy_CollapsesAndCaches(t *testing.T) {
+	p, upstream, release := testProxy(t, nil)
+
+	var wg sync.WaitGroup
+	responses := make([]*httptest.ResponseRecorder, 5)
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i] = get(p, "/v2/competitions/PL/standings?season=2021", fmt.Sprintf("consumer-%d", i%2))
+		}(i)
+	}
+
+	// Wait for the callers to queue behind the upstream call.
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(upstream) == 0 {
+		if time.Now().After(deadline) {
+			close(release)
+			t.Fatal("upstream was never called")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	statuses := map[string]int{}
+	for _, rec := range responses {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `{"season": {"id": 2021}}`, rec.Body.String())
+		statuses[rec.Header().Get("X-Cache")]++
+	}
+	assert.Equal(t, map[string]int{"MISS": 1, "COLLAPSED": 4}, statuses)
+	assert.Equal(t, int32(1), atomic.LoadInt32(upstream))
+
+	rec := get(p, "/v2/competitions/PL/standings?season=2021", "consumer-0")
+	assert.Equal(t, "HIT", rec.Header().Get("X-Cache"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(upstream))
+
+	first, second := tokenName("consumer-0"), tokenName("consumer-1")
+	usage := p.Usage()
+	assert.Equal(t, 4, usage[first].Requests)
+	assert.Equal(t, 1, usage[first].CacheHits)
+	assert.Equal(t, 2, usage[second].Requests)
+	assert.Equal(t, 1, usage[first].Upstream+usage[second].Upstream)
+}
+
+func TestProxy_PassesErrorsThrough(t *testing.T) {
+	p, upstream, _ := testProxy(t, nil)
+
+	for i := 0; i < 2; i++ {
+		rec := get(p, "/v2/teams/0", "a")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "does not exist")
+	}
+
+	// Errors are not cached.
+	assert.Equal(t, int32(2), atomic.LoadInt32(upstream))
+	assert.Equal(t, 2, p.Usage()[tokenName("a")].Errors)
+}
+
+func TestProxy_TTL(t *testing.T) {
+	p, upstream, _ := testProxy(t, &Options{TTL: time.Hour, TTLs: map[string]time.Duration{"areas": time.Nanosecond}})
+
+	get(p, "/v2/areas", "")
+	time.Sleep(time.Millisecond)
+	rec := get(p, "/v2/areas", "")
+
+	assert.Equal(t, "MISS", rec.Header().Get("X-Cache"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(upstream))
+	assert.Equal(t, time.Hour, p.ttl("competitions"))
+}
+
+func TestProxy_Consumers(t *testing.T) {
+	p, _, _ := testProxy(t, &Options{Consumers: map[string]string{"secret": "reports"}})
+
+	rec := get(p, "/v2/areas", "other")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = get(p, "/v2/areas", "secret")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = get(p, "/stats", "secret")
+	body, _ := ioutil.ReadAll(rec.Body)
+	assert.Contains(t, string(body), `"reports":{"requests":1`)
+}
+
+func TestProxy_Stats(t *testing.T) {
+	p, _, _ := testProxy(t, &Options{AdminToken: "admin"})
+
+	get(p, "/v2/areas", "consumer-secret")
+
+	for _, token := range []string{"", "consumer-secret"} {
+		rec := get(p, "/stats", token)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	}
+
+	rec := get(p, "/stats", "admin")
+	body, _ := ioutil.ReadAll(rec.Body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, string(body), tokenName("consumer-secret"))
+	assert.NotContains(t, string(body), "consumer-secret")
+}
+
+func TestCache_Evicts(t *testing.T) {
+	c := newCache(2)
+	now := time.Now()
+
+	c.set("a", []byte("a"), now.Add(time.Hour))
+	c.set("b", []byte("b"), now.Add(time.Minute))
+	c.set("c", []byte("c"), now.Add(time.Hour))
+
+	_, ok := c.get("b")
+	assert.False(t, ok)
+	_, ok = c.get("a")
+	assert.True(t, ok)
+	_, ok = c.get("c")
+	assert.True(t, ok)
+}
@@ -0,0 +1,76 @@
+package football
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter spaces requests out evenly so that no more than a limit are
+// made per period. Waiting callers are served in arrival order.
+type RateLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewRateLimiter returns a limiter allowing limit requests per period, for
+// example NewRateLimiter(10, time.Minute) for the free tier.
+func NewRateLimiter(limit int, period time.Duration) *RateLimiter {
+	if limit <= 0 {
+		limit = 1
+	}
+
+	return &RateLimiter{interval: period / time.Duration(limit)}
+}
+
+// Wait blocks until the caller may make a request, or until ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	wait := time.Until(slot)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.release(slot)
+		return ctx.Err()
+	}
+}
+
+// Queued returns how long a request made now would wait.
+func (l *RateLimiter) Queued() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if wait := time.Until(l.next); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// release gives back the slot of a caller that stopped waiting, when no
+// later slot was handed out meanwhile.
+func (l *RateLimiter) release(slot time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.next.Equal(slot.Add(l.interval)) {
+		l.next = slot
+	}
+}
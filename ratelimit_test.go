@@ -0,0 +1,37 @@
+package football
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := NewRateLimiter(50, time.Second)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		assert.Nil(t, limiter.Wait(ctx))
+	}
+
+	// The first request goes through at once, the next three 20ms apart.
+	assert.True(t, time.Since(start) >= 60*time.Millisecond)
+	assert.True(t, limiter.Queued() > 0)
+}
+
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	limiter := NewRateLimiter(1, time.Hour)
+	assert.Nil(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx)
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+	// The abandoned slot is given back.
+	assert.True(t, limiter.Queued() <= time.Hour)
+}
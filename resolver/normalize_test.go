@@ -0,0 +1,30 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"Série A":             "serie a",
+		"  Primera  División": "primera division",
+		"Bayern München":      "bayern munchen",
+		"Atlético-MG":         "atletico mg",
+		"1. FC Köln":          "1 fc koln",
+		"Fortuna Düsseldorf":  "fortuna dusseldorf",
+		"!!!":                 "",
+	}
+
+	for input, expected := range tests {
+		assert.Equal(t, expected, normalize(input), input)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, distance("flamengo", "flamengo"))
+	assert.Equal(t, 1, distance("flamengo", "flamengu"))
+	assert.Equal(t, 2, distance("gremio", "grmeio"))
+	assert.Equal(t, 3, distance("", "abc"))
+}
@@ -0,0 +1,263 @@
+// Package resolver maps what users type, such as "PL", "Premier League",
+// "FLA" or "Flamengo", to competition and team IDs.
+//
+// Codes, TLAs, names and short names are matched exactly first, ignoring
+// case, accents and punctuation, then by words and by edit distance. When
+// several entries match equally well the match is reported as ambiguous
+// rather than guessed.
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// Kind is the kind of an Entry.
+type Kind string
+
+const (
+	KindCompetition Kind = "competition"
+	KindTeam        Kind = "team"
+)
+
+// ErrNotFound is returned when nothing matches a query.
+var ErrNotFound = errors.New("No competition or team matches")
+
+// Entry is a competition or team of the index.
+type Entry struct {
+	Kind Kind
+	ID   int
+	Name string
+	// Code is the competition code or the team TLA.
+	Code string
+}
+
+// String returns the ID as the services expect it.
+func (e Entry) String() string {
+	return strconv.Itoa(e.ID)
+}
+
+// AmbiguousError is returned when several entries match a query equally
+// well.
+type AmbiguousError struct {
+	Query      string
+	Candidates []Entry
+}
+
+func (e *AmbiguousError) Error() string {
+	names := make([]string, len(e.Candidates))
+	for i, candidate := range e.Candidates {
+		names[i] = fmt.Sprintf("%s %d (%s)", candidate.Kind, candidate.ID, candidate.Name)
+	}
+	return fmt.Sprintf("%q is ambiguous: %s", e.Query, strings.Join(names, ", "))
+}
+
+// Index resolves queries to competitions and teams.
+type Index struct {
+	entries []Entry
+	// keys holds the normalized names of every entry.
+	keys [][]string
+	// exact maps normalized codes and names to entries.
+	exact map[string][]int
+	ids   map[Kind]map[int]int
+}
+
+// New returns an empty index.
+func New() *Index {
+	return &Index{
+		exact: map[string][]int{},
+		ids:   map[Kind]map[int]int{KindCompetition: {}, KindTeam: {}},
+	}
+}
+
+// Build indexes the competitions listed by the client and the teams of the
+// given competitions, by code or ID.
+func Build(ctx context.Context, client *football.Client, competitions []string) (*Index, error) {
+	index := New()
+
+	list, err := client.Competitions.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, competition := range list.Competitions {
+		index.AddCompetition(competition)
+	}
+
+	for _, competition := range competitions {
+		teams, err := client.Competitions.Teams(ctx, competition, nil)
+		if err != nil {
+			return nil, fmt.Errorf("teams of %s: %w", competition, err)
+		}
+
+		for _, team := range teams.Teams {
+			index.AddTeam(team)
+		}
+	}
+
+	return index, nil
+}
+
+// AddCompetition indexes a competition by code and name.
+func (i *Index) AddCompetition(competition football.Competition) {
+	i.add(Entry{Kind: KindCompetition, ID: competition.ID, Name: competition.Name, Code: competition.Code},
+		competition.Code, competition.Name)
+}
+
+// AddTeam indexes a team by TLA, name and short name.
+func (i *Index) AddTeam(team football.Team) {
+	i.add(Entry{Kind: KindTeam, ID: team.ID, Name: team.Name, Code: team.Tla},
+		team.Tla, team.Name, team.ShortName)
+}
+
+func (i *Index) add(entry Entry, names ...string) {
+	position, ok := i.ids[entry.Kind][entry.ID]
+	if !ok {
+		position = len(i.entries)
+		i.entries = append(i.entries, entry)
+		i.keys = append(i.keys, nil)
+		i.ids[entry.Kind][entry.ID] = position
+	} else {
+		i.entries[position] = entry
+	}
+
+	for _, name := range names {
+		key := normalize(name)
+		if key == "" || contains(i.exact[key], position) {
+			continue
+		}
+		i.exact[key] = append(i.exact[key], position)
+		i.keys[position] = append(i.keys[position], key)
+	}
+}
+
+// Resolve returns the competition or team matching query. It fails with
+// ErrNotFound or an *AmbiguousError.
+func (i *Index) Resolve(query string) (Entry, error) {
+	return i.resolve(query, "")
+}
+
+// Competition returns the competition matching query.
+func (i *Index) Competition(query string) (Entry, error) {
+	return i.resolve(query, KindCompetition)
+}
+
+// Team returns the team matching query.
+func (i *Index) Team(query string) (Entry, error) {
+	return i.resolve(query, KindTeam)
+}
+
+func (i *Index) resolve(query string, kind Kind) (Entry, error) {
+	key := normalize(query)
+	if key == "" {
+		return Entry{}, ErrNotFound
+	}
+
+	exact := []int{}
+	for _, position := range i.exact[key] {
+		if kind == "" || i.entries[position].Kind == kind {
+			exact = append(exact, position)
+		}
+	}
+	if len(exact) > 0 {
+		return i.pick(query, exact)
+	}
+
+	best, candidates := -1, []int{}
+	for position, entry := range i.entries {
+		if kind != "" && entry.Kind != kind {
+			continue
+		}
+
+		score := -1
+		for _, name := range i.keys[position] {
+			if s := fuzzyScore(key, name); s >= 0 && (score < 0 || s < score) {
+				score = s
+			}
+		}
+
+		switch {
+		case score < 0:
+		case best < 0 || score < best:
+			best, candidates = score, []int{position}
+		case score == best:
+			candidates = append(candidates, position)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return Entry{}, fmt.Errorf("%w %q", ErrNotFound, query)
+	}
+
+	return i.pick(query, candidates)
+}
+
+func (i *Index) pick(query string, positions []int) (Entry, error) {
+	if len(positions) == 1 {
+		return i.entries[positions[0]], nil
+	}
+
+	candidates := make([]Entry, len(positions))
+	for n, position := range positions {
+		candidates[n] = i.entries[position]
+	}
+	sort.Slice(candidates, func(a, b int) bool {
+		if candidates[a].Kind != candidates[b].Kind {
+			return candidates[a].Kind < candidates[b].Kind
+		}
+		return candidates[a].ID < candidates[b].ID
+	})
+
+	return Entry{}, &AmbiguousError{Query: query, Candidates: candidates}
+}
+
+// fuzzyScore rates how well query matches name, lower is better, or
+// returns -1 when it does not match. Every word of the query must start a
+// word of the name, or be within a small edit distance of one.
+func fuzzyScore(query, name string) int {
+	words := strings.Fields(name)
+	score := 0
+
+	for _, q := range strings.Fields(query) {
+		best := -1
+		for _, word := range words {
+			s := -1
+			switch {
+			case word == q:
+				s = 0
+			case strings.HasPrefix(word, q) && len(q) >= 3:
+				s = 1
+			default:
+				if d := distance(q, word); d <= len(q)/4 && d > 0 {
+					s = 1 + d
+				}
+			}
+			if s >= 0 && (best < 0 || s < best) {
+				best = s
+			}
+		}
+
+		if best < 0 {
+			return -1
+		}
+		score += best
+	}
+
+	// Prefer names with fewer extra words.
+	return score*10 + len(words) - len(strings.Fields(query))
+}
+
+func contains(positions []int, position int) bool {
+	for _, p := range positions {
+		if p == position {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func testIndex() *Index {
+	index := New()
+	index.AddCompetition(football.Competition{ID: 2021, Code: "PL", Name: "Premier League"})
+	index.AddCompetition(football.Competition{ID: 2013, Code: "BSA", Name: "Série A"})
+	index.AddCompetition(football.Competition{ID: 2019, Code: "SA", Name: "Serie A"})
+	index.AddCompetition(football.Competition{ID: 2014, Code: "PD", Name: "Primera Division"})
+	index.AddTeam(football.Team{ID: 1783, Tla: "FLA", Name: "CR Flamengo", ShortName: "Flamengo"})
+	index.AddTeam(football.Team{ID: 1767, Tla: "GRE", Name: "Grêmio FBPA", ShortName: "Grêmio"})
+	index.AddTeam(football.Team{ID: 1766, Tla: "CAM", Name: "CA Mineiro", ShortName: "Mineiro"})
+	index.AddTeam(football.Team{ID: 1768, Tla: "CAP", Name: "CA Paranaense", ShortName: "Paranaense"})
+	index.AddTeam(football.Team{ID: 57, Tla: "ARS", Name: "Arsenal FC", ShortName: "Arsenal"})
+	return index
+}
+
+func TestIndex_Resolve(t *testing.T) {
+	index := testIndex()
+
+	tests := []struct {
+		query string
+		kind  Kind
+		id    int
+	}{
+		{"PL", KindCompetition, 2021},
+		{"pl", KindCompetition, 2021},
+		{"Premier League", KindCompetition, 2021},
+		{"premier", KindCompetition, 2021},
+		{"BSA", KindCompetition, 2013},
+		{"Primera División", KindCompetition, 2014},
+		{"FLA", KindTeam, 1783},
+		{"Flamengo", KindTeam, 1783},
+		{"flamengu", KindTeam, 1783},
+		{"Gremio", KindTeam, 1767},
+		{"arsenal", KindTeam, 57},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			entry, err := index.Resolve(test.query)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.kind, entry.Kind)
+			assert.Equal(t, test.id, entry.ID)
+		})
+	}
+}
+
+func TestIndex_ResolveAmbiguous(t *testing.T) {
+	index := testIndex()
+
+	_, err := index.Resolve("Serie A")
+
+	var ambiguous *AmbiguousError
+	assert.True(t, errors.As(err, &ambiguous))
+	assert.Equal(t, "Serie A", ambiguous.Query)
+	assert.Equal(t, []Entry{
+		{Kind: KindCompetition, ID: 2013, Name: "Série A", Code: "BSA"},
+		{Kind: KindCompetition, ID: 2019, Name: "Serie A", Code: "SA"},
+	}, ambiguous.Candidates)
+	assert.Contains(t, err.Error(), "competition 2013 (Série A)")
+
+	_, err = index.Team("CA")
+	assert.True(t, errors.As(err, &ambiguous))
+	assert.Len(t, ambiguous.Candidates, 2)
+}
+
+func TestIndex_ResolveNotFound(t *testing.T) {
+	index := testIndex()
+
+	for _, query := range []string{"", "Boca Juniors", "PL United"} {
+		_, err := index.Resolve(query)
+		assert.True(t, errors.Is(err, ErrNotFound), query)
+	}
+
+	_, err := index.Team("PL")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	_, err = index.Competition("FLA")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestIndex_AddReplaces(t *testing.T) {
+	index := testIndex()
+	index.AddTeam(football.Team{ID: 1783, Tla: "FLA", Name: "CR Flamengo", ShortName: "Mengão"})
+
+	entry, err := index.Team("Mengao")
+	assert.NoError(t, err)
+	assert.Equal(t, 1783, entry.ID)
+	assert.Equal(t, "1783", entry.String())
+
+	entry, err = index.Team("Flamengo")
+	assert.NoError(t, err)
+	assert.Equal(t, 1783, entry.ID)
+}
+
+func TestBuild(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/competitions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 2, "competitions": [
+			{"id": 2021, "code": "PL", "name": "Premier League"},
+			{"id": 2013, "code": "BSA", "name": "Série A"}
+		]}`)
+	})
+	mux.HandleFunc("/v2/competitions/BSA/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 1, "teams": [
+			{"id": 1783, "tla": "FLA", "name": "CR Flamengo", "shortName": "Flamengo"}
+		]}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := football.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/v2")
+
+	index, err := Build(context.Background(), client, []string{"BSA"})
+	assert.NoError(t, err)
+
+	entry, err := index.Resolve("serie a")
+	assert.NoError(t, err)
+	assert.Equal(t, 2013, entry.ID)
+
+	entry, err = index.Resolve("Flamengo")
+	assert.NoError(t, err)
+	assert.Equal(t, 1783, entry.ID)
+
+	_, err = Build(context.Background(), client, []string{"PL"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "teams of PL")
+}
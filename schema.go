@@ -0,0 +1,268 @@
+package football
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DriftKind tells how a response differs from the Go structs.
+type DriftKind string
+
+const (
+	// UnknownField is a field of the response no struct field receives.
+	UnknownField DriftKind = "unknown field"
+	// TypeMismatch is a value of the response that does not fit the type
+	// of its struct field.
+	TypeMismatch DriftKind = "type mismatch"
+)
+
+// Drift is a difference between a response and the Go structs.
+type Drift struct {
+	Kind DriftKind `json:"kind"`
+	// Field is the path of the field, with [] for array elements, such as
+	// "matches[].score.fullTime.homeTeam".
+	Field string `json:"field"`
+	// Want is the Go type of the field, for type mismatches.
+	Want string `json:"want,omitempty"`
+	// Got is the JSON type found.
+	Got string `json:"got"`
+	// Count is the number of times the drift occurs in the response.
+	Count int `json:"count"`
+}
+
+func (d Drift) String() string {
+	if d.Kind == TypeMismatch {
+		return fmt.Sprintf("%s at %s: got %s, want %s", d.Kind, d.Field, d.Got, d.Want)
+	}
+	return fmt.Sprintf("%s %s (%s)", d.Kind, d.Field, d.Got)
+}
+
+// DecodeError is returned when a response does not fit the Go structs:
+// on type mismatches, and also on unknown fields when the client is
+// Strict.
+type DecodeError struct {
+	// Endpoint is the request path with IDs replaced, such as
+	// "competitions/{id}/standings".
+	Endpoint string
+	Drifts   []Drift
+}
+
+func (e *DecodeError) Error() string {
+	drifts := make([]string, len(e.Drifts))
+	for i, drift := range e.Drifts {
+		drifts[i] = drift.String()
+	}
+	return fmt.Sprintf("Response of %s does not match the schema: %s", e.Endpoint, strings.Join(drifts, "; "))
+}
+
+// Endpoint returns path with its IDs replaced by {id}, such as
+// "teams/{id}/matches" for "teams/1783/matches?status=FINISHED".
+func Endpoint(path string) string {
+	path = strings.Trim(strings.SplitN(path, "?", 2)[0], "/")
+
+	segments := strings.Split(path, "/")
+	if len(segments) > 1 {
+		segments[1] = "{id}"
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// responses maps endpoints to the type service methods decode them into.
+var responses = map[string]reflect.Type{
+	"areas":                       reflect.TypeOf(AreaList{}),
+	"areas/{id}":                  reflect.TypeOf(Area{}),
+	"competitions":                reflect.TypeOf(CompetitionList{}),
+	"competitions/{id}":           reflect.TypeOf(Competition{}),
+	"competitions/{id}/teams":     reflect.TypeOf(CompetitionTeams{}),
+	"competitions/{id}/standings": reflect.TypeOf(CompetitionStandings{}),
+	"competitions/{id}/matches":   reflect.TypeOf(CompetitionMatches{}),
+	"competitions/{id}/scorers":   reflect.TypeOf(CompetitionScorers{}),
+	"matches":                     reflect.TypeOf(MatchesCompetition{}),
+	"matches/{id}":                reflect.TypeOf(MatchResponse{}),
+	"teams/{id}":                  reflect.TypeOf(Team{}),
+	"teams/{id}/matches":          reflect.TypeOf(TeamMatches{}),
+	"players/{id}":                reflect.TypeOf(Player{}),
+	"players/{id}/matches":        reflect.TypeOf(PlayerMatches{}),
+}
+
+// NewResponse returns a pointer to a new value of the type service methods
+// decode the response of path into, or false for paths the SDK does not
+// read.
+func NewResponse(path string) (interface{}, bool) {
+	t, ok := responses[Endpoint(path)]
+	if !ok {
+		return nil, false
+	}
+	return reflect.New(t).Interface(), true
+}
+
+// CheckSchema compares the JSON body with the type of v, reporting every
+// field v has no place for and every value of the wrong type. The error
+// is only set when body is not valid JSON.
+func CheckSchema(body []byte, v interface{}) ([]Drift, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	checker := &schemaChecker{drifts: map[Drift]int{}}
+	checker.check("", value, reflect.TypeOf(v))
+
+	drifts := make([]Drift, 0, len(checker.drifts))
+	for drift, count := range checker.drifts {
+		drift.Count = count
+		drifts = append(drifts, drift)
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		if drifts[i].Field != drifts[j].Field {
+			return drifts[i].Field < drifts[j].Field
+		}
+		return drifts[i].Kind < drifts[j].Kind
+	})
+
+	return drifts, nil
+}
+
+type schemaChecker struct {
+	drifts map[Drift]int
+}
+
+var (
+	jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func (c *schemaChecker) check(field string, value interface{}, t reflect.Type) {
+	if t == nil || value == nil {
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if reflect.PtrTo(t).Implements(jsonUnmarshaler) {
+		return
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Interface:
+		case reflect.Struct:
+			for name, element := range value {
+				fieldType, ok := jsonField(t, name)
+				if !ok {
+					c.drifts[Drift{Kind: UnknownField, Field: join(field, name), Got: jsonType(element)}]++
+					continue
+				}
+				c.check(join(field, name), element, fieldType)
+			}
+		case reflect.Map:
+			for name, element := range value {
+				c.check(join(field, name), element, t.Elem())
+			}
+		default:
+			c.mismatch(field, value, t)
+		}
+	case []interface{}:
+		switch t.Kind() {
+		case reflect.Interface:
+		case reflect.Slice, reflect.Array:
+			for _, element := range value {
+				c.check(field+"[]", element, t.Elem())
+			}
+		default:
+			c.mismatch(field, value, t)
+		}
+	case string:
+		if t.Kind() != reflect.String && t.Kind() != reflect.Interface && !reflect.PtrTo(t).Implements(textUnmarshaler) {
+			c.mismatch(field, value, t)
+		}
+	case bool:
+		if t.Kind() != reflect.Bool && t.Kind() != reflect.Interface {
+			c.mismatch(field, value, t)
+		}
+	case json.Number:
+		switch t.Kind() {
+		case reflect.Interface, reflect.Float32, reflect.Float64:
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if _, err := value.Int64(); err != nil {
+				c.mismatch(field, value, t)
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if _, err := value.Int64(); err != nil || strings.HasPrefix(string(value), "-") {
+				c.mismatch(field, value, t)
+			}
+		default:
+			c.mismatch(field, value, t)
+		}
+	}
+}
+
+func (c *schemaChecker) mismatch(field string, value interface{}, t reflect.Type) {
+	c.drifts[Drift{Kind: TypeMismatch, Field: field, Want: t.String(), Got: jsonType(value)}]++
+}
+
+// jsonField returns the type of the field of struct t receiving the JSON
+// name, matched like encoding/json does: exactly first, then ignoring
+// case.
+func jsonField(t reflect.Type, name string) (reflect.Type, bool) {
+	var folded reflect.Type
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+		if tag == "" {
+			tag = field.Name
+		}
+
+		if tag == name {
+			return field.Type, true
+		}
+		if folded == nil && strings.EqualFold(tag, name) {
+			folded = field.Type
+		}
+	}
+
+	return folded, folded != nil
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	default:
+		return "number"
+	}
+}
+
+func join(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
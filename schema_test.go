@@ -0,0 +1,105 @@
+package football
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpoint(t *testing.T) {
+	assert.Equal(t, "competitions", Endpoint("competitions"))
+	assert.Equal(t, "competitions/{id}/standings", Endpoint("/competitions/PL/standings?season=2021"))
+	assert.Equal(t, "teams/{id}", Endpoint("teams/1783"))
+}
+
+func TestNewResponse(t *testing.T) {
+	v, ok := NewResponse("teams/1783/matches?status=FINISHED")
+	assert.True(t, ok)
+	assert.IsType(t, &TeamMatches{}, v)
+
+	_, ok = NewResponse("webhooks/1")
+	assert.False(t, ok)
+}
+
+func TestCheckSchema(t *testing.T) {
+	body := []byte(`{
+		"count": 2,
+		"filters": {"season": "2021"},
+		"matches": [
+			{"id": 1, "minute": "45+2", "odds": {"homeWin": 1.5}, "score": {"fullTime": {"homeTeam": 1, "awayTeam": null}}},
+			{"id": "2", "odds": {"homeWin": 2.1}, "score": {"fullTime": {"homeTeam": 1.5}}, "goals": {}},
+			{"ID": 3, "homeTeam": {"squad": [{"shirtNumber": -1, "id": 1e3}]}}
+		]
+	}`)
+
+	drifts, err := CheckSchema(body, &CompetitionMatches{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []Drift{
+		{Kind: TypeMismatch, Field: "matches[].goals", Want: "[]football.Goals", Got: "object", Count: 1},
+		{Kind: TypeMismatch, Field: "matches[].homeTeam.squad[].id", Want: "int64", Got: "number", Count: 1},
+		{Kind: TypeMismatch, Field: "matches[].id", Want: "int", Got: "string", Count: 1},
+		{Kind: UnknownField, Field: "matches[].odds", Got: "object", Count: 2},
+		{Kind: TypeMismatch, Field: "matches[].score.fullTime.homeTeam", Want: "int", Got: "number", Count: 1},
+	}, drifts)
+
+	assert.Equal(t, "unknown field matches[].odds (object)", drifts[3].String())
+	assert.Equal(t, "type mismatch at matches[].id: got string, want int", drifts[2].String())
+
+	_, err = CheckSchema([]byte(`{"count":`), &CompetitionMatches{})
+	assert.NotNil(t, err)
+}
+
+func TestClient_Decode(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/teams/1783", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1783, "name": "CR Flamengo", "runningCompetitions": []}`)
+	})
+	mux.HandleFunc("/v2/teams/1766", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1766, "founded": "1908"}`)
+	})
+
+	client := NewClient(httpClient)
+	ctx := context.Background()
+
+	// Unknown fields are ignored by default.
+	team, err := client.Teams.Find(ctx, "1783")
+	assert.Nil(t, err)
+	assert.Equal(t, "CR Flamengo", team.Name)
+
+	// Type mismatches are always reported.
+	_, err = client.Teams.Find(ctx, "1766")
+	var decodeErr *DecodeError
+	assert.True(t, errors.As(err, &decodeErr))
+	assert.Equal(t, "teams/{id}", decodeErr.Endpoint)
+	assert.Equal(t, []Drift{{Kind: TypeMismatch, Field: "founded", Want: "int", Got: "string", Count: 1}}, decodeErr.Drifts)
+	assert.Equal(t, "Response of teams/{id} does not match the schema: type mismatch at founded: got string, want int", err.Error())
+
+	client.Strict = true
+
+	_, err = client.Teams.Find(ctx, "1783")
+	assert.True(t, errors.As(err, &decodeErr))
+	assert.Equal(t, []Drift{{Kind: UnknownField, Field: "runningCompetitions", Got: "array", Count: 1}}, decodeErr.Drifts)
+}
+
+func TestClient_DecodeSnapshot(t *testing.T) {
+	snapshot := NewSnapshot()
+	snapshot.Add("areas/2072", []byte(`{"id": 2072, "name": "England", "flag": "eng.svg"}`))
+
+	client := NewClient(nil)
+	client.Snapshot = snapshot
+	client.Strict = true
+
+	_, err := client.Areas.Find(context.Background(), "2072")
+
+	var decodeErr *DecodeError
+	assert.True(t, errors.As(err, &decodeErr))
+	assert.Equal(t, "areas/{id}", decodeErr.Endpoint)
+	assert.Equal(t, "flag", decodeErr.Drifts[0].Field)
+}
@@ -0,0 +1,175 @@
+package simulation
+
+import (
+	"math"
+	"math/rand"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// Model simulates the score of a match between two teams, identified by
+// their IDs.
+type Model interface {
+	Score(r *rand.Rand, home, away int) (homeGoals, awayGoals int)
+}
+
+// Uniform gives a home win, a draw and an away win the same probability.
+type Uniform struct{}
+
+// Score returns 1-0, 0-0 or 0-1 with equal probability.
+func (Uniform) Score(r *rand.Rand, home, away int) (int, int) {
+	switch r.Intn(3) {
+	case 0:
+		return 1, 0
+	case 1:
+		return 0, 0
+	default:
+		return 0, 1
+	}
+}
+
+// Elo derives the outcome probabilities from the rating difference of the
+// teams.
+type Elo struct {
+	Ratings       map[int]float64 // Ratings by team ID.
+	Default       float64         // Rating of teams missing from Ratings, defaults to 1500.
+	HomeAdvantage float64         // Rating points added to the home team.
+	DrawRate      float64         // Draw probability between equal teams, defaults to 0.26.
+}
+
+// Probabilities returns the probabilities of a home win, a draw and an
+// away win.
+func (m *Elo) Probabilities(home, away int) (float64, float64, float64) {
+	diff := m.rating(home) + m.HomeAdvantage - m.rating(away)
+	expected := 1 / (1 + math.Pow(10, -diff/400))
+
+	drawRate := m.DrawRate
+	if drawRate <= 0 {
+		drawRate = 0.26
+	}
+
+	// Draws get less likely as the gap between the teams grows.
+	draw := drawRate * (1 - math.Abs(2*expected-1))
+	return expected - draw/2, draw, 1 - expected - draw/2
+}
+
+// Score samples an outcome and returns 1-0, 0-0 or 0-1 accordingly.
+func (m *Elo) Score(r *rand.Rand, home, away int) (int, int) {
+	win, draw, _ := m.Probabilities(home, away)
+
+	p := r.Float64()
+	switch {
+	case p < win:
+		return 1, 0
+	case p < win+draw:
+		return 0, 0
+	default:
+		return 0, 1
+	}
+}
+
+func (m *Elo) rating(team int) float64 {
+	if rating, ok := m.Ratings[team]; ok {
+		return rating
+	}
+	if m.Default > 0 {
+		return m.Default
+	}
+	return 1500
+}
+
+// Poisson draws the goals of each team from a Poisson distribution whose
+// mean depends on the attack strength of the team and the defence
+// strength of its opponent.
+type Poisson struct {
+	HomeGoals float64         // Average goals scored by home teams.
+	AwayGoals float64         // Average goals scored by away teams.
+	Attack    map[int]float64 // Attack strength by team ID, 1 is average.
+	Defence   map[int]float64 // Defence weakness by team ID, 1 is average.
+}
+
+// NewPoisson estimates a Poisson model from the HOME and AWAY tables of
+// standings. When those are not available the TOTAL table is used and
+// home and away teams share the same averages.
+func NewPoisson(standings *football.CompetitionStandings) *Poisson {
+	home, away := table(standings, football.StandingTypeHome), table(standings, football.StandingTypeAway)
+	if home == nil || away == nil {
+		home = table(standings, football.StandingTypeTotal)
+		away = home
+	}
+
+	m := &Poisson{Attack: map[int]float64{}, Defence: map[int]float64{}}
+	m.HomeGoals = average(home)
+	m.AwayGoals = average(away)
+
+	ratio := func(goals, played int, avg float64) float64 {
+		if played == 0 || avg == 0 {
+			return 1
+		}
+		return float64(goals) / float64(played) / avg
+	}
+
+	for _, row := range home {
+		m.Attack[row.Team.ID] = ratio(row.GoalsFor, row.PlayedGames, m.HomeGoals) / 2
+		m.Defence[row.Team.ID] = ratio(row.GoalsAgainst, row.PlayedGames, m.AwayGoals) / 2
+	}
+	for _, row := range away {
+		m.Attack[row.Team.ID] += ratio(row.GoalsFor, row.PlayedGames, m.AwayGoals) / 2
+		m.Defence[row.Team.ID] += ratio(row.GoalsAgainst, row.PlayedGames, m.HomeGoals) / 2
+	}
+
+	return m
+}
+
+// Score samples the goals of each team.
+func (m *Poisson) Score(r *rand.Rand, home, away int) (int, int) {
+	homeMean := m.HomeGoals * strength(m.Attack, home) * strength(m.Defence, away)
+	awayMean := m.AwayGoals * strength(m.Attack, away) * strength(m.Defence, home)
+
+	return poisson(r, homeMean), poisson(r, awayMean)
+}
+
+func strength(strengths map[int]float64, team int) float64 {
+	if s, ok := strengths[team]; ok {
+		return s
+	}
+	return 1
+}
+
+// poisson samples a Poisson distributed number with the given mean.
+func poisson(r *rand.Rand, mean float64) int {
+	limit, p, n := math.Exp(-mean), 1.0, 0
+	for {
+		p *= r.Float64()
+		if p <= limit {
+			return n
+		}
+		n++
+	}
+}
+
+// table returns the table of standingType, nil when standings has none.
+func table(standings *football.CompetitionStandings, standingType football.StandingType) []football.Table {
+	if standings == nil {
+		return nil
+	}
+	for _, standing := range standings.Standings {
+		if standing.Type == string(standingType) {
+			return standing.Table
+		}
+	}
+	return nil
+}
+
+// average returns the goals scored per team per match.
+func average(rows []football.Table) float64 {
+	goals, played := 0, 0
+	for _, row := range rows {
+		goals += row.GoalsFor
+		played += row.PlayedGames
+	}
+	if played == 0 {
+		return 0
+	}
+	return float64(goals) / float64(played)
+}
@@ -0,0 +1,229 @@
+// Package simulation estimates the final positions of a league with a
+// Monte Carlo simulation of its remaining fixtures.
+package simulation
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"runtime"
+	"sync"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/matheustex/football-data-sdk/standings"
+)
+
+const defaultIterations = 10000
+
+// Config controls a simulation run.
+type Config struct {
+	Model       Model                  // Outcome model, defaults to Uniform.
+	Iterations  int                    // Number of simulated seasons, defaults to 10000.
+	Seed        int64                  // Seed of the random numbers; equal seeds give equal results.
+	Workers     int                    // Goroutines used, defaults to the number of CPUs.
+	TieBreakers []standings.TieBreaker // Defaults to goal difference and goals for.
+}
+
+// TeamResult holds the simulated outcomes of a team.
+type TeamResult struct {
+	Team             football.Team `json:"team"`
+	Positions        []float64     `json:"positions"` // Probability of finishing in each position, first position first.
+	ExpectedPoints   float64       `json:"expectedPoints"`
+	ExpectedPosition float64       `json:"expectedPosition"`
+}
+
+// Top returns the probability of finishing in the first n positions.
+// Top(1) is the probability of winning the title.
+func (r TeamResult) Top(n int) float64 {
+	p := 0.0
+	for i := 0; i < n && i < len(r.Positions); i++ {
+		p += r.Positions[i]
+	}
+	return p
+}
+
+// Bottom returns the probability of finishing in the last n positions,
+// such as the relegation places.
+func (r TeamResult) Bottom(n int) float64 {
+	p := 0.0
+	for i := len(r.Positions) - 1; i >= 0 && i >= len(r.Positions)-n; i-- {
+		p += r.Positions[i]
+	}
+	return p
+}
+
+// Result holds the outcome of a simulation, with teams in the order of
+// the current table.
+type Result struct {
+	Iterations int          `json:"iterations"`
+	Teams      []TeamResult `json:"teams"`
+}
+
+// Run simulates the remaining fixtures on top of the TOTAL table of
+// current. Finished and canceled fixtures are skipped, as are fixtures
+// involving teams outside the table.
+func Run(ctx context.Context, current *football.CompetitionStandings, fixtures []football.Match, cfg Config) (*Result, error) {
+	base := table(current, football.StandingTypeTotal)
+	if len(base) == 0 {
+		return nil, errors.New("Standings must contain a TOTAL table")
+	}
+
+	if cfg.Model == nil {
+		cfg.Model = Uniform{}
+	}
+	if cfg.Iterations <= 0 {
+		cfg.Iterations = defaultIterations
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = runtime.NumCPU()
+	}
+	if cfg.TieBreakers == nil {
+		cfg.TieBreakers = []standings.TieBreaker{standings.GoalDifference, standings.GoalsFor}
+	}
+
+	index := map[int]int{}
+	for i, row := range base {
+		index[row.Team.ID] = i
+	}
+
+	remaining := []fixture{}
+	for _, match := range fixtures {
+		if match.Status == string(football.StatusFinished) || match.Status == string(football.StatusCanceled) {
+			continue
+		}
+		if match.HomeTeam == nil || match.AwayTeam == nil {
+			continue
+		}
+		home, okHome := index[match.HomeTeam.ID]
+		away, okAway := index[match.AwayTeam.ID]
+		if okHome && okAway {
+			remaining = append(remaining, fixture{home: home, away: away})
+		}
+	}
+
+	tallies := make([]*tally, cfg.Workers)
+	errs := make([]error, cfg.Workers)
+
+	var wg sync.WaitGroup
+	for w := 0; w < cfg.Workers; w++ {
+		tallies[w] = newTally(len(base))
+
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+
+			// Each iteration has its own seed, so results do not depend on
+			// the number of workers or on how goroutines are scheduled.
+			for i := w; i < cfg.Iterations; i += cfg.Workers {
+				if err := ctx.Err(); err != nil {
+					errs[w] = err
+					return
+				}
+
+				r := rand.New(rand.NewSource(cfg.Seed + int64(i)))
+				season(r, base, remaining, cfg, tallies[w])
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	total := newTally(len(base))
+	for _, t := range tallies {
+		total.merge(t)
+	}
+
+	return total.result(base, cfg.Iterations), nil
+}
+
+type fixture struct {
+	home, away int
+}
+
+// season simulates one season and records the final positions.
+func season(r *rand.Rand, base []football.Table, remaining []fixture, cfg Config, t *tally) {
+	rows := make([]football.Table, len(base))
+	copy(rows, base)
+
+	for _, f := range remaining {
+		homeGoals, awayGoals := cfg.Model.Score(r, rows[f.home].Team.ID, rows[f.away].Team.ID)
+		record(&rows[f.home], homeGoals, awayGoals)
+		record(&rows[f.away], awayGoals, homeGoals)
+	}
+
+	final := make([]football.Table, len(rows))
+	copy(final, rows)
+	standings.Sort(final, nil, cfg.TieBreakers)
+
+	positions := map[int]int{}
+	for i, row := range final {
+		positions[row.Team.ID] = i
+	}
+	for i, row := range rows {
+		t.positions[i][positions[row.Team.ID]]++
+		t.points[i] += row.Points
+	}
+}
+
+func record(row *football.Table, scored, conceded int) {
+	row.PlayedGames++
+	row.GoalsFor += scored
+	row.GoalsAgainst += conceded
+	row.GoalDifference = row.GoalsFor - row.GoalsAgainst
+
+	switch {
+	case scored > conceded:
+		row.Won++
+		row.Points += 3
+	case scored == conceded:
+		row.Draw++
+		row.Points++
+	default:
+		row.Lost++
+	}
+}
+
+// tally counts the outcomes of the simulated seasons. Counts are integers
+// so merging tallies is exact and independent of their order.
+type tally struct {
+	positions [][]int
+	points    []int
+}
+
+func newTally(teams int) *tally {
+	t := &tally{positions: make([][]int, teams), points: make([]int, teams)}
+	for i := range t.positions {
+		t.positions[i] = make([]int, teams)
+	}
+	return t
+}
+
+func (t *tally) merge(other *tally) {
+	for i := range t.positions {
+		for j := range t.positions[i] {
+			t.positions[i][j] += other.positions[i][j]
+		}
+		t.points[i] += other.points[i]
+	}
+}
+
+func (t *tally) result(base []football.Table, iterations int) *Result {
+	result := &Result{Iterations: iterations, Teams: make([]TeamResult, len(base))}
+
+	for i, row := range base {
+		team := TeamResult{Team: row.Team, Positions: make([]float64, len(base))}
+		for pos, count := range t.positions[i] {
+			team.Positions[pos] = float64(count) / float64(iterations)
+			team.ExpectedPosition += float64(pos+1) * team.Positions[pos]
+		}
+		team.ExpectedPoints = float64(t.points[i]) / float64(iterations)
+		result.Teams[i] = team
+	}
+
+	return result
+}
@@ -0,0 +1,123 @@
+package simulation
+
+import (
+	"context"
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func row(id, points, goalsFor, goalsAgainst int) football.Table {
+	return football.Table{
+		Team:           football.Team{ID: id},
+		PlayedGames:    10,
+		Points:         points,
+		GoalsFor:       goalsFor,
+		GoalsAgainst:   goalsAgainst,
+		GoalDifference: goalsFor - goalsAgainst,
+	}
+}
+
+func scheduled(home, away int) football.Match {
+	return football.Match{
+		Status:   string(football.StatusScheduled),
+		HomeTeam: &football.Team{ID: home},
+		AwayTeam: &football.Team{ID: away},
+	}
+}
+
+func league() (*football.CompetitionStandings, []football.Match) {
+	current := &football.CompetitionStandings{
+		Standings: []football.Standing{
+			{Type: "TOTAL", Table: []football.Table{
+				row(1, 30, 25, 5),
+				row(2, 20, 15, 10),
+				row(3, 19, 12, 12),
+				row(4, 5, 4, 29),
+			}},
+		},
+	}
+
+	fixtures := []football.Match{
+		scheduled(2, 3),
+		scheduled(3, 4),
+		scheduled(4, 2),
+		scheduled(1, 4),
+	}
+
+	return current, fixtures
+}
+
+func TestRun(t *testing.T) {
+	current, fixtures := league()
+
+	result, err := Run(context.Background(), current, fixtures, Config{Iterations: 2000, Seed: 1})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2000, result.Iterations)
+	assert.Len(t, result.Teams, 4)
+
+	// The leader is out of reach and the bottom team cannot climb.
+	assert.Equal(t, 1.0, result.Teams[0].Top(1))
+	assert.Equal(t, 1.0, result.Teams[3].Bottom(1))
+
+	second, third := result.Teams[1], result.Teams[2]
+	assert.InDelta(t, 1.0, second.Positions[1]+third.Positions[1], 1e-9)
+	assert.True(t, second.Positions[1] > third.Positions[1])
+	assert.True(t, second.ExpectedPoints > 20 && second.ExpectedPoints < 26)
+}
+
+func TestRun_Deterministic(t *testing.T) {
+	current, fixtures := league()
+	model := NewPoisson(current)
+
+	first, err := Run(context.Background(), current, fixtures, Config{Model: model, Iterations: 500, Seed: 42, Workers: 1})
+	assert.Nil(t, err)
+
+	second, err := Run(context.Background(), current, fixtures, Config{Model: model, Iterations: 500, Seed: 42, Workers: 7})
+	assert.Nil(t, err)
+
+	assert.Equal(t, first, second)
+}
+
+func TestRun_Canceled(t *testing.T) {
+	current, fixtures := league()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Run(ctx, current, fixtures, Config{})
+
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestRun_WithoutStandings(t *testing.T) {
+	_, fixtures := league()
+
+	_, err := Run(context.Background(), nil, fixtures, Config{})
+
+	assert.EqualError(t, err, "Standings must contain a TOTAL table")
+}
+
+func TestElo_Probabilities(t *testing.T) {
+	m := &Elo{Ratings: map[int]float64{1: 1700, 2: 1500}}
+
+	win, draw, loss := m.Probabilities(1, 2)
+	assert.InDelta(t, 1.0, win+draw+loss, 1e-9)
+	assert.True(t, win > loss)
+
+	win, draw, loss = m.Probabilities(3, 4)
+	assert.InDelta(t, 0.37, win, 1e-9)
+	assert.InDelta(t, 0.26, draw, 1e-9)
+	assert.InDelta(t, 0.37, loss, 1e-9)
+}
+
+func TestNewPoisson(t *testing.T) {
+	current, _ := league()
+	m := NewPoisson(current)
+
+	assert.InDelta(t, 1.4, m.HomeGoals, 1e-9)
+	assert.InDelta(t, 25.0/10/1.4, m.Attack[1], 1e-9)
+	assert.InDelta(t, 29.0/10/1.4, m.Defence[4], 1e-9)
+}
@@ -0,0 +1,370 @@
+package football
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+const (
+	// APIVersion is the version of the API the client talks to.
+	APIVersion = "v2"
+	// SnapshotFormat is the version of the snapshot layout written by
+	// this package.
+	SnapshotFormat = 1
+
+	snapshotManifest = "manifest.json"
+)
+
+// ErrSnapshotMiss is returned when a client reading from a snapshot makes
+// a request the snapshot does not hold.
+var ErrSnapshotMiss = errors.New("Request not found in snapshot")
+
+// SnapshotManifest describes a snapshot.
+type SnapshotManifest struct {
+	Format     int       `json:"format"`
+	APIVersion string    `json:"apiVersion"`
+	CapturedAt time.Time `json:"capturedAt"`
+	// Entries maps request keys, as returned by SnapshotKey, to the file
+	// holding the response body.
+	Entries map[string]string `json:"entries"`
+}
+
+// Snapshot holds API responses keyed by request path and query, so a
+// Client can serve reads without network. On disk it is a directory, or a
+// .tar.gz of that directory, with a manifest.json and one JSON file per
+// response.
+type Snapshot struct {
+	Manifest SnapshotManifest
+
+	mu     sync.RWMutex
+	bodies map[string][]byte
+}
+
+// NewSnapshot returns an empty snapshot captured now.
+func NewSnapshot() *Snapshot {
+	return &Snapshot{
+		Manifest: SnapshotManifest{
+			Format:     SnapshotFormat,
+			APIVersion: APIVersion,
+			CapturedAt: time.Now().UTC(),
+			Entries:    map[string]string{},
+		},
+		bodies: map[string][]byte{},
+	}
+}
+
+// SnapshotKey returns the key of the request Client.Get makes for path
+// and params.
+func SnapshotKey(path string, params interface{}) (string, error) {
+	encoded, err := encodeParams(params)
+	if err != nil {
+		return "", err
+	}
+
+	key := strings.TrimPrefix(path, "/")
+	if encoded != "" {
+		key += "?" + encoded
+	}
+
+	return key, nil
+}
+
+// Add stores body as the response to the request key.
+func (s *Snapshot) Add(key string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file := strings.Replace(key, "?", "@", 1) + ".json"
+
+	s.Manifest.Entries[key] = file
+	s.bodies[key] = body
+}
+
+// Keys returns the request keys held by the snapshot, sorted.
+func (s *Snapshot) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.bodies))
+	for key := range s.bodies {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Lookup returns the response to the request key. The error wraps
+// ErrSnapshotMiss when the snapshot does not hold it.
+func (s *Snapshot) Lookup(key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	body, ok := s.bodies[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (captured %s)", ErrSnapshotMiss, key, s.Manifest.CapturedAt.Format(time.RFC3339))
+	}
+
+	return body, nil
+}
+
+// Recorder returns a RoundTripper that sends requests through next, or
+// http.DefaultTransport when nil, and adds every successful API response
+// to the snapshot. Give it to the http.Client of a Client to capture what
+// the service methods read.
+func (s *Snapshot) Recorder(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &snapshotRecorder{snapshot: s, next: next}
+}
+
+type snapshotRecorder struct {
+	snapshot *Snapshot
+	next     http.RoundTripper
+}
+
+func (r *snapshotRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := r.next.RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusOK {
+		return res, err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	key := strings.TrimPrefix(req.URL.Path, "/"+APIVersion+"/")
+	if req.URL.RawQuery != "" {
+		key += "?" + req.URL.RawQuery
+	}
+	r.snapshot.Add(key, body)
+
+	return res, nil
+}
+
+// LoadSnapshot reads a snapshot from a directory or a .tar.gz file.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+
+	if info.IsDir() {
+		err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+
+			name, err := filepath.Rel(path, file)
+			if err != nil {
+				return err
+			}
+
+			files[filepath.ToSlash(name)], err = ioutil.ReadFile(file)
+			return err
+		})
+	} else {
+		files, err = readTarGz(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newSnapshotFromFiles(files)
+}
+
+func newSnapshotFromFiles(files map[string][]byte) (*Snapshot, error) {
+	manifest, ok := files[snapshotManifest]
+	if !ok {
+		return nil, errors.New("Snapshot has no " + snapshotManifest)
+	}
+
+	s := &Snapshot{bodies: map[string][]byte{}}
+	if err := json.Unmarshal(manifest, &s.Manifest); err != nil {
+		return nil, fmt.Errorf("Invalid snapshot manifest: %v", err)
+	}
+
+	if s.Manifest.Format > SnapshotFormat {
+		return nil, fmt.Errorf("Unsupported snapshot format %d, this client reads up to %d", s.Manifest.Format, SnapshotFormat)
+	}
+
+	if s.Manifest.APIVersion != APIVersion {
+		return nil, fmt.Errorf("Snapshot was captured from API %s, this client uses %s", s.Manifest.APIVersion, APIVersion)
+	}
+
+	if s.Manifest.Entries == nil {
+		s.Manifest.Entries = map[string]string{}
+	}
+
+	for key, file := range s.Manifest.Entries {
+		body, ok := files[file]
+		if !ok {
+			return nil, fmt.Errorf("Snapshot file %s for %s is missing", file, key)
+		}
+		s.bodies[key] = body
+	}
+
+	return s, nil
+}
+
+func readTarGz(path string) (map[string][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	files := map[string][]byte{}
+	archive := tar.NewReader(gz)
+
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		files[strings.TrimPrefix(header.Name, "./")], err = ioutil.ReadAll(archive)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Save writes the snapshot to path, as a .tar.gz when path ends with
+// .tar.gz or .tgz and as a directory otherwise.
+func (s *Snapshot) Save(path string) error {
+	if strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz") {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+
+		if err := s.WriteTarGz(f); err != nil {
+			f.Close()
+			return err
+		}
+
+		return f.Close()
+	}
+
+	return s.WriteDir(path)
+}
+
+// WriteDir writes the snapshot files under dir.
+func (s *Snapshot) WriteDir(dir string) error {
+	return s.writeFiles(func(name string, data []byte) error {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(file, data, 0644)
+	})
+}
+
+// WriteTarGz writes the snapshot files as a gzipped tarball to w.
+func (s *Snapshot) WriteTarGz(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+
+	err := s.writeFiles(func(name string, data []byte) error {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: s.Manifest.CapturedAt,
+		}
+		if err := archive.WriteHeader(header); err != nil {
+			return err
+		}
+
+		_, err := archive.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := archive.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// writeFiles calls write with the manifest and every response file.
+func (s *Snapshot) writeFiles(write func(name string, data []byte) error) error {
+	manifest, err := json.MarshalIndent(s.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := write(snapshotManifest, manifest); err != nil {
+		return err
+	}
+
+	for _, key := range s.Keys() {
+		if strings.Contains(s.Manifest.Entries[key], "..") {
+			return fmt.Errorf("Invalid snapshot key %s", key)
+		}
+		if err := write(s.Manifest.Entries[key], s.bodies[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodeParams encodes the filters of a request as a query string.
+func encodeParams(params interface{}) (string, error) {
+	if params == nil {
+		return "", nil
+	}
+
+	if values, ok := params.(url.Values); ok {
+		return values.Encode(), nil
+	}
+
+	values, err := query.Values(params)
+	if err != nil {
+		return "", err
+	}
+
+	return values.Encode(), nil
+}
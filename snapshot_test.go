@@ -0,0 +1,107 @@
+package football
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recordSnapshot(t *testing.T) (*Snapshot, *Competition, *MatchesCompetition) {
+	httpClient, mux, server := testServer()
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/v2/competitions/2013", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 2013, "name": "Série A", "code": "BSA"}`)
+	})
+
+	mux.HandleFunc("/v2/matches", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 1, "matches": [{"id": 1, "status": "FINISHED"}]}`)
+	})
+
+	snapshot := NewSnapshot()
+	client := NewClient(&http.Client{Transport: snapshot.Recorder(httpClient.Transport)})
+	ctx := context.Background()
+
+	competition, err := client.Competitions.Find(ctx, "2013")
+	assert.Nil(t, err)
+
+	matches, err := client.Matches.List(ctx, &MatchesFiltersOptions{Status: "FINISHED", Competitions: "2013"})
+	assert.Nil(t, err)
+
+	_, err = client.Competitions.Find(ctx, "404")
+	assert.NotNil(t, err)
+
+	return snapshot, competition, matches
+}
+
+func TestSnapshot_Recorder(t *testing.T) {
+	snapshot, _, _ := recordSnapshot(t)
+
+	assert.Equal(t, []string{"competitions/2013", "matches?competitions=2013&status=FINISHED"}, snapshot.Keys())
+	assert.Equal(t, APIVersion, snapshot.Manifest.APIVersion)
+	assert.Equal(t, SnapshotFormat, snapshot.Manifest.Format)
+	assert.False(t, snapshot.Manifest.CapturedAt.IsZero())
+}
+
+func TestClient_Snapshot(t *testing.T) {
+	snapshot, competition, matches := recordSnapshot(t)
+
+	for _, name := range []string{"snapshot", "snapshot.tar.gz"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			assert.Nil(t, snapshot.Save(path))
+
+			loaded, err := LoadSnapshot(path)
+			assert.Nil(t, err)
+			assert.Equal(t, snapshot.Manifest.CapturedAt.Unix(), loaded.Manifest.CapturedAt.Unix())
+
+			// Reading from a snapshot needs no token.
+			token := os.Getenv("FOOTBALL_API_TOKEN")
+			os.Unsetenv("FOOTBALL_API_TOKEN")
+			defer os.Setenv("FOOTBALL_API_TOKEN", token)
+
+			client := NewClient(nil)
+			client.Snapshot = loaded
+			ctx := context.Background()
+
+			found, err := client.Competitions.Find(ctx, "2013")
+			assert.Nil(t, err)
+			assert.Equal(t, competition, found)
+
+			list, err := client.Matches.List(ctx, &MatchesFiltersOptions{Competitions: "2013", Status: "FINISHED"})
+			assert.Nil(t, err)
+			assert.Equal(t, matches, list)
+
+			_, err = client.Matches.List(ctx, nil)
+			assert.True(t, errors.Is(err, ErrSnapshotMiss))
+			assert.True(t, ErrorContains(err, "matches"))
+		})
+	}
+}
+
+func TestLoadSnapshot_RejectsOtherAPIVersions(t *testing.T) {
+	dir := t.TempDir()
+	manifest := `{"format": 1, "apiVersion": "v4", "capturedAt": "2021-08-01T00:00:00Z", "entries": {}}`
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "manifest.json"), []byte(manifest), 0644))
+
+	_, err := LoadSnapshot(dir)
+
+	assert.True(t, ErrorContains(err, "captured from API v4"))
+}
+
+func TestLoadSnapshot_MissingFile(t *testing.T) {
+	dir := t.TempDir()
+	manifest := `{"format": 1, "apiVersion": "v2", "entries": {"areas": "areas.json"}}`
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "manifest.json"), []byte(manifest), 0644))
+
+	_, err := LoadSnapshot(dir)
+
+	assert.True(t, ErrorContains(err, "areas.json for areas is missing"))
+}
@@ -0,0 +1,186 @@
+// Package squad detects roster changes between two snapshots of a team,
+// such as the team stored yesterday and the one returned by
+// TeamService.Find today.
+package squad
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// Kind is the kind of a Change.
+type Kind string
+
+const (
+	// Joined is a player in the new squad only, a transfer in.
+	Joined Kind = "joined"
+	// Left is a player in the old squad only, a transfer out.
+	Left Kind = "left"
+	// ShirtNumber is a player whose shirt number changed.
+	ShirtNumber Kind = "shirtNumber"
+	// Position is a player whose position changed.
+	Position Kind = "position"
+	// Role is a squad member whose role changed, such as a player
+	// becoming assistant coach.
+	Role Kind = "role"
+	// Coach is a change of head coach.
+	Coach Kind = "coach"
+	// Captain is a change of captain.
+	Captain Kind = "captain"
+)
+
+// order is the order of the kinds in a Log.
+var order = map[Kind]int{Coach: 0, Captain: 1, Left: 2, Joined: 3, Role: 4, Position: 5, ShirtNumber: 6}
+
+// Change is a single change to a team.
+type Change struct {
+	Kind Kind `json:"kind"`
+	// PersonID and Name are the player, or the new coach or captain.
+	PersonID int64  `json:"personId,omitempty"`
+	Name     string `json:"name,omitempty"`
+	// From and To are the values before and after the change: shirt
+	// numbers, positions, roles, or coach and captain names.
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case Joined, Left:
+		return fmt.Sprintf("%s %s", c.Name, c.Kind)
+	case Coach, Captain:
+		return fmt.Sprintf("%s: %s -> %s", c.Kind, orNone(c.From), orNone(c.To))
+	default:
+		return fmt.Sprintf("%s %s: %s -> %s", c.Name, c.Kind, orNone(c.From), orNone(c.To))
+	}
+}
+
+// Log lists the changes between two snapshots of a team.
+type Log struct {
+	TeamID   int    `json:"teamId"`
+	TeamName string `json:"teamName"`
+	// From and To are the LastUpdated of the old and new snapshots.
+	From    string   `json:"from,omitempty"`
+	To      string   `json:"to,omitempty"`
+	Changes []Change `json:"changes"`
+}
+
+// Count returns the number of changes of kind.
+func (l *Log) Count(kind Kind) int {
+	count := 0
+	for _, change := range l.Changes {
+		if change.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// Diff compares two snapshots of the same team. Squad, coach and captain
+// changes are only reported when both snapshots have them, so a team
+// fetched or stored without them, as the store keeps no coach or captain,
+// does not read as everybody leaving or a new coach arriving.
+func Diff(old, current football.Team) *Log {
+	log := &Log{
+		TeamID:   current.ID,
+		TeamName: current.Name,
+		From:     old.LastUpdated,
+		To:       current.LastUpdated,
+		Changes:  []Change{},
+	}
+
+	if old.Coach != nil && current.Coach != nil {
+		if change, ok := diffCoach(old.Coach, current.Coach); ok {
+			log.Changes = append(log.Changes, change)
+		}
+	}
+	if old.Captain != nil && current.Captain != nil {
+		if change, ok := diffCaptain(old.Captain, current.Captain); ok {
+			log.Changes = append(log.Changes, change)
+		}
+	}
+
+	if old.Squad != nil && current.Squad != nil {
+		log.Changes = append(log.Changes, diffSquad(*old.Squad, *current.Squad)...)
+	}
+
+	sort.SliceStable(log.Changes, func(i, j int) bool {
+		a, b := log.Changes[i], log.Changes[j]
+		if order[a.Kind] != order[b.Kind] {
+			return order[a.Kind] < order[b.Kind]
+		}
+		return a.PersonID < b.PersonID
+	})
+
+	return log
+}
+
+func diffCoach(old, current *football.Coach) (Change, bool) {
+	if old.ID == current.ID {
+		return Change{}, false
+	}
+	return Change{Kind: Coach, PersonID: int64(current.ID), Name: current.Name, From: old.Name, To: current.Name}, true
+}
+
+func diffCaptain(old, current *football.Player) (Change, bool) {
+	if old.ID == current.ID {
+		return Change{}, false
+	}
+	return Change{Kind: Captain, PersonID: current.ID, Name: current.Name, From: old.Name, To: current.Name}, true
+}
+
+func diffSquad(old, current []football.Player) []Change {
+	changes := []Change{}
+
+	previous := map[int64]football.Player{}
+	for _, player := range old {
+		previous[player.ID] = player
+	}
+
+	present := map[int64]bool{}
+	for _, player := range current {
+		present[player.ID] = true
+
+		before, ok := previous[player.ID]
+		if !ok {
+			changes = append(changes, Change{Kind: Joined, PersonID: player.ID, Name: player.Name, To: player.Position})
+			continue
+		}
+
+		if before.Role != player.Role {
+			changes = append(changes, Change{Kind: Role, PersonID: player.ID, Name: player.Name, From: before.Role, To: player.Role})
+		}
+		if before.Position != player.Position {
+			changes = append(changes, Change{Kind: Position, PersonID: player.ID, Name: player.Name, From: before.Position, To: player.Position})
+		}
+		if before.ShirtNumber != player.ShirtNumber {
+			changes = append(changes, Change{Kind: ShirtNumber, PersonID: player.ID, Name: player.Name,
+				From: shirtNumber(before.ShirtNumber), To: shirtNumber(player.ShirtNumber)})
+		}
+	}
+
+	for _, player := range old {
+		if !present[player.ID] {
+			changes = append(changes, Change{Kind: Left, PersonID: player.ID, Name: player.Name, From: player.Position})
+		}
+	}
+
+	return changes
+}
+
+func shirtNumber(number int) string {
+	if number == 0 {
+		return ""
+	}
+	return strconv.Itoa(number)
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
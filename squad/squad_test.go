@@ -0,0 +1,95 @@
+package squad
+
+import (
+	"encoding/json"
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func team(coach *football.Coach, captain *football.Player, squad ...football.Player) football.Team {
+	return football.Team{ID: 1783, Name: "CR Flamengo", Coach: coach, Captain: captain, Squad: &squad}
+}
+
+func TestDiff(t *testing.T) {
+	gabigol := football.Player{ID: 1, Name: "Gabriel Barbosa", Position: "Attacker", ShirtNumber: 9, Role: "PLAYER"}
+	arrascaeta := football.Player{ID: 2, Name: "Giorgian De Arrascaeta", Position: "Midfielder", ShirtNumber: 14, Role: "PLAYER"}
+	diego := football.Player{ID: 3, Name: "Diego", Position: "Midfielder", ShirtNumber: 10, Role: "PLAYER"}
+	pedro := football.Player{ID: 4, Name: "Pedro", Position: "Attacker", ShirtNumber: 21, Role: "PLAYER"}
+
+	old := team(&football.Coach{ID: 10, Name: "Rogério Ceni"}, &diego, gabigol, arrascaeta, diego)
+	old.LastUpdated = "2021-07-01T00:00:00Z"
+
+	gabigolNow := gabigol
+	gabigolNow.ShirtNumber = 10
+	arrascaetaNow := arrascaeta
+	arrascaetaNow.Position = "Attacker"
+
+	current := team(&football.Coach{ID: 11, Name: "Renato Gaúcho"}, &gabigolNow, gabigolNow, arrascaetaNow, pedro)
+	current.LastUpdated = "2021-08-01T00:00:00Z"
+
+	log := Diff(old, current)
+
+	assert.Equal(t, 1783, log.TeamID)
+	assert.Equal(t, "2021-07-01T00:00:00Z", log.From)
+	assert.Equal(t, "2021-08-01T00:00:00Z", log.To)
+	assert.Equal(t, []Change{
+		{Kind: Coach, PersonID: 11, Name: "Renato Gaúcho", From: "Rogério Ceni", To: "Renato Gaúcho"},
+		{Kind: Captain, PersonID: 1, Name: "Gabriel Barbosa", From: "Diego", To: "Gabriel Barbosa"},
+		{Kind: Left, PersonID: 3, Name: "Diego", From: "Midfielder"},
+		{Kind: Joined, PersonID: 4, Name: "Pedro", To: "Attacker"},
+		{Kind: Position, PersonID: 2, Name: "Giorgian De Arrascaeta", From: "Midfielder", To: "Attacker"},
+		{Kind: ShirtNumber, PersonID: 1, Name: "Gabriel Barbosa", From: "9", To: "10"},
+	}, log.Changes)
+	assert.Equal(t, 1, log.Count(Joined))
+
+	strings := []string{}
+	for _, change := range log.Changes {
+		strings = append(strings, change.String())
+	}
+	assert.Equal(t, []string{
+		"coach: Rogério Ceni -> Renato Gaúcho",
+		"captain: Diego -> Gabriel Barbosa",
+		"Diego left",
+		"Pedro joined",
+		"Giorgian De Arrascaeta position: Midfielder -> Attacker",
+		"Gabriel Barbosa shirtNumber: 9 -> 10",
+	}, strings)
+
+	body, err := json.Marshal(log.Changes[3])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"kind": "joined", "personId": 4, "name": "Pedro", "to": "Attacker"}`, string(body))
+}
+
+func TestDiff_Role(t *testing.T) {
+	player := football.Player{ID: 5, Name: "Diego Ribas", Role: "PLAYER"}
+	staff := player
+	staff.Role = "ASSISTANT_COACH"
+
+	log := Diff(team(nil, nil, player), team(nil, nil, staff))
+
+	assert.Equal(t, []Change{
+		{Kind: Role, PersonID: 5, Name: "Diego Ribas", From: "PLAYER", To: "ASSISTANT_COACH"},
+	}, log.Changes)
+}
+
+func TestDiff_WithoutCoach(t *testing.T) {
+	gabigol := football.Player{ID: 1, Name: "Gabriel Barbosa"}
+
+	// Stored teams have no coach or captain.
+	stored := team(nil, nil, gabigol)
+	live := team(&football.Coach{ID: 10, Name: "Rogério Ceni"}, &gabigol, gabigol)
+
+	assert.Empty(t, Diff(stored, live).Changes)
+	assert.Empty(t, Diff(live, stored).Changes)
+}
+
+func TestDiff_WithoutSquad(t *testing.T) {
+	old := team(nil, nil, football.Player{ID: 1, Name: "Gabriel Barbosa"})
+	current := football.Team{ID: 1783, Name: "CR Flamengo"}
+
+	log := Diff(old, current)
+
+	assert.Empty(t, log.Changes)
+}
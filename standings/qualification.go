@@ -0,0 +1,169 @@
+package standings
+
+import (
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// QualificationStatus is the outcome of a group for a team.
+type QualificationStatus string
+
+const (
+	Qualified  QualificationStatus = "QUALIFIED"
+	Playoff    QualificationStatus = "PLAYOFF"
+	Eliminated QualificationStatus = "ELIMINATED"
+	Undecided  QualificationStatus = "UNDECIDED"
+)
+
+// QualificationRules describes how many teams of a group go through.
+type QualificationRules struct {
+	Qualify int // Teams qualifying directly, e.g. the top two.
+	Playoff int // Teams after those going to a play-off or a best third ranking.
+}
+
+// Qualification is the status of a team in a group.
+type Qualification struct {
+	Team      football.Team       `json:"team"`
+	Position  int                 `json:"position"`
+	Points    int                 `json:"points"`
+	MaxPoints int                 `json:"maxPoints"`
+	Status    QualificationStatus `json:"status"`
+}
+
+// Qualify returns the qualification status of each team of a group table
+// given the group fixtures still to be played. A status is only given once
+// it cannot change whatever the remaining results; teams level on points
+// are assumed to lose the tie-breakers until every fixture is played.
+func Qualify(table []football.Table, remaining []football.Match, rules QualificationRules) []Qualification {
+	pending := []fixture{}
+	left := map[int]int{}
+	for _, match := range remaining {
+		if match.Status == string(football.StatusFinished) || match.Status == string(football.StatusCanceled) {
+			continue
+		}
+		if match.HomeTeam == nil || match.AwayTeam == nil {
+			continue
+		}
+		pending = append(pending, fixture{home: match.HomeTeam.ID, away: match.AwayTeam.ID})
+		left[match.HomeTeam.ID]++
+		left[match.AwayTeam.ID]++
+	}
+
+	result := make([]Qualification, len(table))
+	points := map[int]int{}
+	for i, row := range table {
+		result[i] = Qualification{
+			Team:      row.Team,
+			Position:  row.Position,
+			Points:    row.Points,
+			MaxPoints: row.Points + 3*left[row.Team.ID],
+		}
+		points[row.Team.ID] = row.Points
+	}
+
+	best, worst := map[int]int{}, map[int]int{}
+	switch {
+	case len(pending) == 0:
+		for _, q := range result {
+			best[q.Team.ID], worst[q.Team.ID] = q.Position, q.Position
+		}
+	case len(pending) <= maxEnumerated:
+		enumerate(result, pending, points, best, worst)
+	default:
+		for i, q := range result {
+			best[q.Team.ID], worst[q.Team.ID] = bounds(result, i)
+		}
+	}
+
+	for i, q := range result {
+		result[i].Status = rules.status(best[q.Team.ID], worst[q.Team.ID])
+	}
+
+	return result
+}
+
+// maxEnumerated is the largest number of fixtures whose outcomes are all
+// enumerated, 3^10 combinations.
+const maxEnumerated = 10
+
+type fixture struct {
+	home, away int
+}
+
+// enumerate plays every combination of outcomes of the pending fixtures
+// and records the best and worst position of each team.
+func enumerate(teams []Qualification, pending []fixture, points map[int]int, best, worst map[int]int) {
+	for _, q := range teams {
+		best[q.Team.ID], worst[q.Team.ID] = len(teams), 1
+	}
+
+	var play func(n int)
+	play = func(n int) {
+		if n == len(pending) {
+			for _, q := range teams {
+				above, level := 0, 0
+				for _, other := range teams {
+					if other.Team.ID == q.Team.ID {
+						continue
+					}
+					if points[other.Team.ID] > points[q.Team.ID] {
+						above++
+					} else if points[other.Team.ID] == points[q.Team.ID] {
+						level++
+					}
+				}
+				if above+1 < best[q.Team.ID] {
+					best[q.Team.ID] = above + 1
+				}
+				if above+level+1 > worst[q.Team.ID] {
+					worst[q.Team.ID] = above + level + 1
+				}
+			}
+			return
+		}
+
+		f := pending[n]
+		for _, outcome := range [][2]int{{3, 0}, {1, 1}, {0, 3}} {
+			points[f.home] += outcome[0]
+			points[f.away] += outcome[1]
+			play(n + 1)
+			points[f.home] -= outcome[0]
+			points[f.away] -= outcome[1]
+		}
+	}
+
+	play(0)
+}
+
+// bounds returns the best and worst positions team i can still finish in,
+// without considering which teams play each other.
+func bounds(teams []Qualification, i int) (int, int) {
+	above, below := 0, 0
+	for j, other := range teams {
+		if j == i {
+			continue
+		}
+		// Teams that already have more points than i can reach stay above.
+		if other.Points > teams[i].MaxPoints {
+			above++
+		}
+		// Teams that can reach the points of i may finish above it.
+		if other.MaxPoints >= teams[i].Points {
+			below++
+		}
+	}
+
+	return above + 1, below + 1
+}
+
+func (r QualificationRules) status(best, worst int) QualificationStatus {
+	switch {
+	case worst <= r.Qualify:
+		return Qualified
+	case best > r.Qualify+r.Playoff:
+		return Eliminated
+	case best > r.Qualify && worst <= r.Qualify+r.Playoff:
+		return Playoff
+	default:
+		return Undecided
+	}
+}
@@ -0,0 +1,68 @@
+package standings
+
+import (
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func groupRow(position, id, points int) football.Table {
+	return football.Table{Position: position, Team: football.Team{ID: id}, Points: points}
+}
+
+func statuses(qualifications []Qualification) []QualificationStatus {
+	result := []QualificationStatus{}
+	for _, q := range qualifications {
+		result = append(result, q.Status)
+	}
+	return result
+}
+
+func TestQualify(t *testing.T) {
+	table := []football.Table{
+		groupRow(1, 1, 6),
+		groupRow(2, 2, 3),
+		groupRow(3, 3, 3),
+		groupRow(4, 4, 0),
+	}
+	remaining := []football.Match{
+		{Status: "SCHEDULED", HomeTeam: &football.Team{ID: 1}, AwayTeam: &football.Team{ID: 4}},
+		{Status: "SCHEDULED", HomeTeam: &football.Team{ID: 2}, AwayTeam: &football.Team{ID: 3}},
+	}
+
+	result := Qualify(table, remaining, QualificationRules{Qualify: 2, Playoff: 1})
+
+	assert.Equal(t, []QualificationStatus{Qualified, Undecided, Undecided, Undecided}, statuses(result))
+	assert.Equal(t, 9, result[0].MaxPoints)
+	assert.Equal(t, 3, result[3].MaxPoints)
+}
+
+func TestQualify_Eliminated(t *testing.T) {
+	table := []football.Table{
+		groupRow(1, 1, 9),
+		groupRow(2, 2, 8),
+		groupRow(3, 3, 4),
+		groupRow(4, 4, 0),
+	}
+	remaining := []football.Match{
+		{Status: "SCHEDULED", HomeTeam: &football.Team{ID: 3}, AwayTeam: &football.Team{ID: 4}},
+	}
+
+	result := Qualify(table, remaining, QualificationRules{Qualify: 2, Playoff: 1})
+
+	assert.Equal(t, []QualificationStatus{Qualified, Qualified, Playoff, Eliminated}, statuses(result))
+}
+
+func TestQualify_Complete(t *testing.T) {
+	table := []football.Table{
+		groupRow(1, 1, 7),
+		groupRow(2, 2, 4),
+		groupRow(3, 3, 4),
+		groupRow(4, 4, 1),
+	}
+
+	result := Qualify(table, nil, QualificationRules{Qualify: 2, Playoff: 1})
+
+	assert.Equal(t, []QualificationStatus{Qualified, Qualified, Playoff, Eliminated}, statuses(result))
+}
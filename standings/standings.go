@@ -0,0 +1,366 @@
+// Package standings computes league tables locally from match results.
+//
+// Tables are built in the same format returned by the standings endpoint,
+// so they can be cross-checked against CompetitionService.Standings, and
+// can be computed as of any matchday or date range.
+package standings
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// TieBreaker is a criterion used to order teams level on points.
+type TieBreaker string
+
+const (
+	GoalDifference TieBreaker = "GOAL_DIFFERENCE"
+	GoalsFor       TieBreaker = "GOALS_FOR"
+	Wins           TieBreaker = "WINS"
+	HeadToHead     TieBreaker = "HEAD_TO_HEAD"
+
+	// byPoints is always applied before the configured tie-breakers.
+	byPoints TieBreaker = "POINTS"
+)
+
+// DefaultTieBreakers are used for competitions without specific rules.
+var DefaultTieBreakers = []TieBreaker{GoalDifference, GoalsFor, HeadToHead}
+
+// CompetitionTieBreakers holds the tie-breakers of known competitions,
+// keyed by competition code. Entries can be added or replaced to match
+// the regulations of other competitions.
+var CompetitionTieBreakers = map[string][]TieBreaker{
+	"PL":  {GoalDifference, GoalsFor, HeadToHead},
+	"ELC": {GoalDifference, GoalsFor, HeadToHead},
+	"BL1": {GoalDifference, GoalsFor, HeadToHead},
+	"FL1": {GoalDifference, GoalsFor, HeadToHead},
+	"DED": {GoalDifference, GoalsFor, HeadToHead},
+	"PPL": {HeadToHead, GoalDifference, GoalsFor},
+	"PD":  {HeadToHead, GoalDifference, GoalsFor},
+	"SA":  {HeadToHead, GoalDifference, GoalsFor},
+	"BSA": {Wins, GoalDifference, GoalsFor, HeadToHead},
+}
+
+// Options controls which matches are counted and how teams are ordered.
+type Options struct {
+	Type        football.StandingType // TOTAL, HOME or AWAY, defaults to TOTAL.
+	Matchday    int                   // Count matches up to this matchday, zero counts all.
+	From        time.Time             // Count matches played on or after From.
+	To          time.Time             // Count matches played on or before To.
+	Stage       string                // Only consider matches of this stage.
+	Group       string                // Only consider matches of this group.
+	TieBreakers []TieBreaker          // Defaults to the competition rules or DefaultTieBreakers.
+	Win         int                   // Points for a win, defaults to 3.
+	Draw        int                   // Points for a draw, defaults to 1.
+}
+
+// Compute builds a Standing from matches. Every team playing in the
+// considered matches is listed, but only finished matches accepted by
+// opts are counted.
+func Compute(matches []football.Match, opts *Options) football.Standing {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	standingType := opts.Type
+	if len(standingType) == 0 {
+		standingType = football.StandingTypeTotal
+	}
+
+	considered := []football.Match{}
+	for _, match := range matches {
+		if len(opts.Stage) > 0 && match.Stage != opts.Stage {
+			continue
+		}
+		if len(opts.Group) > 0 && match.Group != opts.Group {
+			continue
+		}
+		considered = append(considered, match)
+	}
+
+	counted := []football.Match{}
+	for _, match := range considered {
+		if opts.counts(match) {
+			counted = append(counted, match)
+		}
+	}
+
+	rows := tabulate(considered, counted, standingType, opts.points())
+	order(rows, counted, standingType, opts)
+
+	table := make([]football.Table, len(rows))
+	for i, row := range rows {
+		row.Position = i + 1
+		table[i] = row.Table
+	}
+
+	standing := football.Standing{
+		Stage: opts.Stage,
+		Type:  string(standingType),
+		Group: opts.Group,
+		Table: table,
+	}
+	if len(standing.Stage) == 0 && len(considered) > 0 {
+		standing.Stage = considered[0].Stage
+	}
+
+	return standing
+}
+
+// Sort orders rows by points and then by tieBreakers, using matches to
+// resolve head-to-head comparisons, and updates their positions.
+func Sort(rows []football.Table, matches []football.Match, tieBreakers []TieBreaker) {
+	teams := make([]*row, len(rows))
+	for i := range rows {
+		teams[i] = &row{Table: rows[i]}
+	}
+
+	opts := &Options{TieBreakers: tieBreakers}
+	order(teams, finished(matches), football.StandingTypeTotal, opts)
+
+	for i, team := range teams {
+		team.Position = i + 1
+		rows[i] = team.Table
+	}
+}
+
+type row struct {
+	football.Table
+}
+
+type points struct {
+	win, draw int
+}
+
+func (o *Options) points() points {
+	p := points{win: 3, draw: 1}
+	if o.Win > 0 {
+		p.win = o.Win
+	}
+	if o.Draw > 0 {
+		p.draw = o.Draw
+	}
+	return p
+}
+
+func (o *Options) counts(match football.Match) bool {
+	if !isFinished(match) {
+		return false
+	}
+	if o.Matchday > 0 && match.Matchday > o.Matchday {
+		return false
+	}
+	if o.From.IsZero() && o.To.IsZero() {
+		return true
+	}
+
+	date, err := time.Parse(time.RFC3339, match.UtcDate)
+	if err != nil {
+		return false
+	}
+	if !o.From.IsZero() && date.Before(o.From) {
+		return false
+	}
+	if !o.To.IsZero() && date.After(o.To) {
+		return false
+	}
+	return true
+}
+
+func (o *Options) tieBreakers(matches []football.Match) []TieBreaker {
+	if o.TieBreakers != nil {
+		return o.TieBreakers
+	}
+	for _, match := range matches {
+		if match.Competition == nil {
+			continue
+		}
+		if rules, ok := CompetitionTieBreakers[match.Competition.Code]; ok {
+			return rules
+		}
+	}
+	return DefaultTieBreakers
+}
+
+func isFinished(match football.Match) bool {
+	return match.Status == string(football.StatusFinished) &&
+		match.Score != nil && match.HomeTeam != nil && match.AwayTeam != nil
+}
+
+func finished(matches []football.Match) []football.Match {
+	result := []football.Match{}
+	for _, match := range matches {
+		if isFinished(match) {
+			result = append(result, match)
+		}
+	}
+	return result
+}
+
+// tabulate lists every team of considered and accumulates the results of
+// counted for the given standing type.
+func tabulate(considered, counted []football.Match, standingType football.StandingType, p points) []*row {
+	rows := []*row{}
+	index := map[int]*row{}
+
+	add := func(team *football.Team) {
+		if team == nil || team.ID == 0 {
+			return
+		}
+		if _, ok := index[team.ID]; ok {
+			return
+		}
+		r := &row{Table: football.Table{Team: football.Team{
+			ID:       team.ID,
+			Name:     team.Name,
+			CrestURL: team.CrestURL,
+		}}}
+		index[team.ID] = r
+		rows = append(rows, r)
+	}
+
+	for _, match := range considered {
+		add(match.HomeTeam)
+		add(match.AwayTeam)
+	}
+
+	for _, match := range counted {
+		// Matches with a team missing, or without ID, have no rows.
+		if match.Score == nil || match.HomeTeam == nil || match.AwayTeam == nil {
+			continue
+		}
+		homeRow, awayRow := index[match.HomeTeam.ID], index[match.AwayTeam.ID]
+		if homeRow == nil || awayRow == nil {
+			continue
+		}
+
+		home, away := match.Score.FullTime.HomeTeam, match.Score.FullTime.AwayTeam
+
+		if standingType != football.StandingTypeAway {
+			homeRow.record(home, away, p)
+		}
+		if standingType != football.StandingTypeHome {
+			awayRow.record(away, home, p)
+		}
+	}
+
+	return rows
+}
+
+func (r *row) record(scored, conceded int, p points) {
+	r.PlayedGames++
+	r.GoalsFor += scored
+	r.GoalsAgainst += conceded
+	r.GoalDifference = r.GoalsFor - r.GoalsAgainst
+
+	switch {
+	case scored > conceded:
+		r.Won++
+		r.Points += p.win
+	case scored == conceded:
+		r.Draw++
+		r.Points += p.draw
+	default:
+		r.Lost++
+	}
+}
+
+// order sorts rows by points and then applies each tie-breaker to the
+// groups of teams that are still level.
+func order(rows []*row, matches []football.Match, standingType football.StandingType, opts *Options) {
+	criteria := append([]TieBreaker{byPoints}, opts.tieBreakers(matches)...)
+	rank(rows, criteria, matches, standingType, opts.points())
+}
+
+func rank(rows []*row, criteria []TieBreaker, matches []football.Match, standingType football.StandingType, p points) {
+	if len(rows) < 2 {
+		return
+	}
+
+	if len(criteria) == 0 {
+		sort.SliceStable(rows, func(i, j int) bool {
+			return strings.ToLower(rows[i].Team.Name) < strings.ToLower(rows[j].Team.Name)
+		})
+		return
+	}
+
+	keys := criterionKeys(rows, criteria[0], matches, standingType, p)
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareKeys(keys[rows[i].Team.ID], keys[rows[j].Team.ID]) > 0
+	})
+
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && compareKeys(keys[rows[start].Team.ID], keys[rows[end].Team.ID]) == 0 {
+			end++
+		}
+		rank(rows[start:end], criteria[1:], matches, standingType, p)
+		start = end
+	}
+}
+
+// criterionKeys returns the sort key of each team for criterion, higher
+// keys ranking first.
+func criterionKeys(rows []*row, criterion TieBreaker, matches []football.Match, standingType football.StandingType, p points) map[int][]int {
+	keys := map[int][]int{}
+
+	switch criterion {
+	case GoalDifference:
+		for _, r := range rows {
+			keys[r.Team.ID] = []int{r.GoalDifference}
+		}
+	case GoalsFor:
+		for _, r := range rows {
+			keys[r.Team.ID] = []int{r.GoalsFor}
+		}
+	case Wins:
+		for _, r := range rows {
+			keys[r.Team.ID] = []int{r.Won}
+		}
+	case HeadToHead:
+		tied := map[int]bool{}
+		for _, r := range rows {
+			tied[r.Team.ID] = true
+		}
+
+		between := []football.Match{}
+		for _, match := range matches {
+			if tied[match.HomeTeam.ID] && tied[match.AwayTeam.ID] {
+				between = append(between, match)
+			}
+		}
+
+		mini := map[int]*row{}
+		for _, r := range tabulate(between, between, standingType, p) {
+			mini[r.Team.ID] = r
+		}
+		for _, r := range rows {
+			if m, ok := mini[r.Team.ID]; ok {
+				keys[r.Team.ID] = []int{m.Points, m.GoalDifference, m.GoalsFor}
+			} else {
+				keys[r.Team.ID] = []int{0, 0, 0}
+			}
+		}
+	case byPoints:
+		for _, r := range rows {
+			keys[r.Team.ID] = []int{r.Points}
+		}
+	}
+
+	return keys
+}
+
+func compareKeys(a, b []int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] > b[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
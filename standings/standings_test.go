@@ -0,0 +1,148 @@
+package standings
+
+import (
+	"testing"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	arsenal = &football.Team{ID: 57, Name: "Arsenal FC"}
+	chelsea = &football.Team{ID: 61, Name: "Chelsea FC"}
+	everton = &football.Team{ID: 62, Name: "Everton FC"}
+	fulham  = &football.Team{ID: 63, Name: "Fulham FC"}
+)
+
+func match(matchday int, date string, home *football.Team, homeGoals, awayGoals int, away *football.Team) football.Match {
+	return football.Match{
+		Competition: &football.Competition{Code: "PL"},
+		UtcDate:     date,
+		Status:      string(football.StatusFinished),
+		Matchday:    matchday,
+		Stage:       "REGULAR_SEASON",
+		HomeTeam:    home,
+		AwayTeam:    away,
+		Score: &football.Score{
+			FullTime: football.Time{HomeTeam: homeGoals, AwayTeam: awayGoals},
+		},
+	}
+}
+
+func fixtures() []football.Match {
+	scheduled := match(3, "2021-08-28T14:00:00Z", everton, 0, 0, fulham)
+	scheduled.Status = string(football.StatusScheduled)
+
+	return []football.Match{
+		match(1, "2021-08-14T14:00:00Z", arsenal, 2, 0, chelsea),
+		match(1, "2021-08-14T14:00:00Z", everton, 1, 1, fulham),
+		match(2, "2021-08-21T14:00:00Z", chelsea, 3, 0, everton),
+		match(2, "2021-08-21T14:00:00Z", fulham, 0, 1, arsenal),
+		scheduled,
+	}
+}
+
+func TestCompute(t *testing.T) {
+	standing := Compute(fixtures(), nil)
+
+	assert.Equal(t, "REGULAR_SEASON", standing.Stage)
+	assert.Equal(t, "TOTAL", standing.Type)
+	assert.Equal(t, []football.Table{
+		{Position: 1, Team: football.Team{ID: 57, Name: "Arsenal FC"}, PlayedGames: 2, Won: 2, Points: 6, GoalsFor: 3, GoalDifference: 3},
+		{Position: 2, Team: football.Team{ID: 61, Name: "Chelsea FC"}, PlayedGames: 2, Won: 1, Lost: 1, Points: 3, GoalsFor: 3, GoalsAgainst: 2, GoalDifference: 1},
+		{Position: 3, Team: football.Team{ID: 63, Name: "Fulham FC"}, PlayedGames: 2, Draw: 1, Lost: 1, Points: 1, GoalsFor: 1, GoalsAgainst: 2, GoalDifference: -1},
+		{Position: 4, Team: football.Team{ID: 62, Name: "Everton FC"}, PlayedGames: 2, Draw: 1, Lost: 1, Points: 1, GoalsFor: 1, GoalsAgainst: 4, GoalDifference: -3},
+	}, standing.Table)
+}
+
+func TestCompute_Matchday(t *testing.T) {
+	standing := Compute(fixtures(), &Options{Matchday: 1})
+
+	assert.Len(t, standing.Table, 4)
+	assert.Equal(t, 57, standing.Table[0].Team.ID)
+	assert.Equal(t, 1, standing.Table[0].PlayedGames)
+	assert.Equal(t, 61, standing.Table[3].Team.ID)
+}
+
+func TestCompute_DateRange(t *testing.T) {
+	from, _ := time.Parse(time.RFC3339, "2021-08-20T00:00:00Z")
+	standing := Compute(fixtures(), &Options{From: from})
+
+	assert.Equal(t, 61, standing.Table[0].Team.ID)
+	assert.Equal(t, 1, standing.Table[0].PlayedGames)
+}
+
+func TestCompute_HomeAndAway(t *testing.T) {
+	home := Compute(fixtures(), &Options{Type: football.StandingTypeHome})
+	away := Compute(fixtures(), &Options{Type: football.StandingTypeAway})
+
+	assert.Equal(t, "HOME", home.Type)
+	assert.Equal(t, 61, home.Table[0].Team.ID)
+	assert.Equal(t, 1, home.Table[0].PlayedGames)
+
+	assert.Equal(t, "AWAY", away.Type)
+	assert.Equal(t, 57, away.Table[0].Team.ID)
+	assert.Equal(t, 3, away.Table[0].Points)
+}
+
+func TestCompute_MissingTeams(t *testing.T) {
+	withoutTeam := match(1, "2021-08-14T14:00:00Z", arsenal, 2, 0, nil)
+	withoutID := match(1, "2021-08-14T14:00:00Z", &football.Team{Name: "Unknown"}, 1, 0, chelsea)
+	matches := []football.Match{
+		withoutTeam,
+		withoutID,
+		match(2, "2021-08-21T14:00:00Z", chelsea, 3, 0, arsenal),
+	}
+
+	standing := Compute(matches, &Options{TieBreakers: []TieBreaker{HeadToHead}})
+
+	assert.Len(t, standing.Table, 2)
+	assert.Equal(t, 61, standing.Table[0].Team.ID)
+	assert.Equal(t, 1, standing.Table[0].PlayedGames)
+	assert.Equal(t, 1, standing.Table[1].PlayedGames)
+}
+
+func TestCompute_HeadToHead(t *testing.T) {
+	matches := []football.Match{
+		match(1, "2021-08-14T14:00:00Z", arsenal, 0, 1, chelsea),
+		match(2, "2021-08-21T14:00:00Z", arsenal, 5, 0, everton),
+		match(2, "2021-08-21T14:00:00Z", everton, 0, 1, fulham),
+	}
+
+	byGoals := Compute(matches, &Options{TieBreakers: []TieBreaker{GoalDifference}})
+	byHeadToHead := Compute(matches, &Options{TieBreakers: []TieBreaker{HeadToHead, GoalDifference}})
+
+	assert.Equal(t, 57, byGoals.Table[0].Team.ID)
+	assert.Equal(t, 61, byHeadToHead.Table[0].Team.ID)
+}
+
+func TestCompute_CompetitionTieBreakers(t *testing.T) {
+	matches := []football.Match{
+		match(1, "2021-08-14T14:00:00Z", arsenal, 0, 1, chelsea),
+		match(2, "2021-08-21T14:00:00Z", arsenal, 5, 0, everton),
+		match(2, "2021-08-21T14:00:00Z", everton, 0, 1, fulham),
+	}
+
+	assert.Equal(t, 57, Compute(matches, nil).Table[0].Team.ID)
+
+	for i := range matches {
+		matches[i].Competition = &football.Competition{Code: "PD"}
+	}
+	assert.Equal(t, 61, Compute(matches, nil).Table[0].Team.ID)
+}
+
+func TestSort(t *testing.T) {
+	rows := []football.Table{
+		{Position: 1, Team: football.Team{ID: 62, Name: "Everton FC"}, Points: 3, GoalDifference: 1, GoalsFor: 1},
+		{Position: 2, Team: football.Team{ID: 57, Name: "Arsenal FC"}, Points: 3, GoalDifference: 1, GoalsFor: 2},
+		{Position: 3, Team: football.Team{ID: 61, Name: "Chelsea FC"}, Points: 4},
+	}
+
+	Sort(rows, nil, DefaultTieBreakers)
+
+	assert.Equal(t, 61, rows[0].Team.ID)
+	assert.Equal(t, 57, rows[1].Team.ID)
+	assert.Equal(t, 2, rows[1].Position)
+	assert.Equal(t, 62, rows[2].Team.ID)
+}
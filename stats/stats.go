@@ -0,0 +1,279 @@
+// Package stats computes form guides and rolling statistics for a team
+// from its matches.
+package stats
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// Result is the outcome of a match from the point of view of a team.
+type Result string
+
+const (
+	Win  Result = "W"
+	Draw Result = "D"
+	Loss Result = "L"
+)
+
+// Split holds the aggregated numbers of a set of matches.
+type Split struct {
+	Played        int `json:"played"`
+	Won           int `json:"won"`
+	Draw          int `json:"draw"`
+	Lost          int `json:"lost"`
+	Points        int `json:"points"`
+	GoalsFor      int `json:"goalsFor"`
+	GoalsAgainst  int `json:"goalsAgainst"`
+	CleanSheets   int `json:"cleanSheets"`
+	FailedToScore int `json:"failedToScore"`
+
+	// Goals by half. Goals in extra time and penalty shoot-outs are not
+	// attributed to either half.
+	FirstHalfGoalsFor      int `json:"firstHalfGoalsFor"`
+	SecondHalfGoalsFor     int `json:"secondHalfGoalsFor"`
+	FirstHalfGoalsAgainst  int `json:"firstHalfGoalsAgainst"`
+	SecondHalfGoalsAgainst int `json:"secondHalfGoalsAgainst"`
+}
+
+// PointsPerGame returns the average number of points per match.
+func (s Split) PointsPerGame() float64 {
+	return perMatch(s.Points, s.Played)
+}
+
+// GoalsForPerMatch returns the average number of goals scored per match.
+func (s Split) GoalsForPerMatch() float64 {
+	return perMatch(s.GoalsFor, s.Played)
+}
+
+// GoalsAgainstPerMatch returns the average number of goals conceded per
+// match.
+func (s Split) GoalsAgainstPerMatch() float64 {
+	return perMatch(s.GoalsAgainst, s.Played)
+}
+
+// Streak is a run of consecutive matches.
+type Streak struct {
+	Result Result `json:"result,omitempty"`
+	Length int    `json:"length"`
+}
+
+// TeamStats holds the statistics of a team over a set of finished matches.
+type TeamStats struct {
+	TeamID  int      `json:"teamId"`
+	Overall Split    `json:"overall"`
+	Home    Split    `json:"home"`
+	Away    Split    `json:"away"`
+	Results []Result `json:"results"` // Ordered from the oldest to the most recent match.
+
+	CurrentStreak     Streak `json:"currentStreak"`
+	LongestWinStreak  int    `json:"longestWinStreak"`
+	LongestLossStreak int    `json:"longestLossStreak"`
+	LongestUnbeaten   int    `json:"longestUnbeaten"`
+
+	matches []football.Match
+}
+
+// ForTeam computes the statistics of the team with the given ID from
+// matches, such as the ones returned by TeamService.Matches. Matches that
+// are not finished or do not involve the team are ignored.
+func ForTeam(teamID int, matches []football.Match) *TeamStats {
+	played := []football.Match{}
+	for _, match := range matches {
+		if !isFinished(match) {
+			continue
+		}
+		if match.HomeTeam.ID != teamID && match.AwayTeam.ID != teamID {
+			continue
+		}
+		played = append(played, match)
+	}
+
+	sort.SliceStable(played, func(i, j int) bool {
+		return kickoff(played[i]).Before(kickoff(played[j]))
+	})
+
+	stats := &TeamStats{TeamID: teamID, Results: []Result{}, matches: played}
+	for _, match := range played {
+		stats.add(match)
+	}
+
+	return stats
+}
+
+// Last returns the statistics of the team over its n most recent matches.
+func (s *TeamStats) Last(n int) *TeamStats {
+	if n < 0 || n >= len(s.matches) {
+		n = len(s.matches)
+	}
+
+	return ForTeam(s.TeamID, s.matches[len(s.matches)-n:])
+}
+
+// Form returns the results of the n most recent matches, oldest first,
+// as a string such as "WWDLW".
+func (s *TeamStats) Form(n int) string {
+	results := s.Results
+	if n >= 0 && n < len(results) {
+		results = results[len(results)-n:]
+	}
+
+	form := strings.Builder{}
+	for _, result := range results {
+		form.WriteString(string(result))
+	}
+
+	return form.String()
+}
+
+func (s *TeamStats) add(match football.Match) {
+	home := match.HomeTeam.ID == s.TeamID
+	score := match.Score
+
+	goalsFor, goalsAgainst := goals(score.FullTime, score.Penalties, home)
+	firstFor, firstAgainst := goals(score.HalfTime, football.Time{}, home)
+
+	var result Result
+	switch {
+	case goalsFor > goalsAgainst:
+		result = Win
+	case goalsFor < goalsAgainst:
+		result = Loss
+	default:
+		result = Draw
+	}
+
+	// A draw decided on penalties counts as a draw for the form guide.
+	if score.Winner == "DRAW" {
+		result = Draw
+	}
+
+	secondFor := goalsFor - firstFor
+	secondAgainst := goalsAgainst - firstAgainst
+	if score.Duration != "" && score.Duration != "REGULAR" {
+		extraFor, extraAgainst := goals(score.ExtraTime, football.Time{}, home)
+		secondFor -= extraFor
+		secondAgainst -= extraAgainst
+	}
+
+	entry := entry{
+		result:        result,
+		goalsFor:      goalsFor,
+		goalsAgainst:  goalsAgainst,
+		firstFor:      firstFor,
+		firstAgainst:  firstAgainst,
+		secondFor:     nonNegative(secondFor),
+		secondAgainst: nonNegative(secondAgainst),
+	}
+
+	s.Overall.add(entry)
+	if home {
+		s.Home.add(entry)
+	} else {
+		s.Away.add(entry)
+	}
+
+	s.Results = append(s.Results, result)
+	s.updateStreaks()
+}
+
+func (s *TeamStats) updateStreaks() {
+	last := s.Results[len(s.Results)-1]
+
+	if s.CurrentStreak.Result == last {
+		s.CurrentStreak.Length++
+	} else {
+		s.CurrentStreak = Streak{Result: last, Length: 1}
+	}
+
+	switch last {
+	case Win:
+		s.LongestWinStreak = max(s.LongestWinStreak, s.CurrentStreak.Length)
+	case Loss:
+		s.LongestLossStreak = max(s.LongestLossStreak, s.CurrentStreak.Length)
+	}
+
+	unbeaten := 0
+	for i := len(s.Results) - 1; i >= 0 && s.Results[i] != Loss; i-- {
+		unbeaten++
+	}
+	s.LongestUnbeaten = max(s.LongestUnbeaten, unbeaten)
+}
+
+type entry struct {
+	result                   Result
+	goalsFor, goalsAgainst   int
+	firstFor, firstAgainst   int
+	secondFor, secondAgainst int
+}
+
+func (s *Split) add(e entry) {
+	s.Played++
+	s.GoalsFor += e.goalsFor
+	s.GoalsAgainst += e.goalsAgainst
+	s.FirstHalfGoalsFor += e.firstFor
+	s.FirstHalfGoalsAgainst += e.firstAgainst
+	s.SecondHalfGoalsFor += e.secondFor
+	s.SecondHalfGoalsAgainst += e.secondAgainst
+
+	if e.goalsAgainst == 0 {
+		s.CleanSheets++
+	}
+	if e.goalsFor == 0 {
+		s.FailedToScore++
+	}
+
+	switch e.result {
+	case Win:
+		s.Won++
+		s.Points += 3
+	case Draw:
+		s.Draw++
+		s.Points++
+	default:
+		s.Lost++
+	}
+}
+
+// goals returns the goals scored and conceded by the team, leaving out
+// the penalty shoot-out goals.
+func goals(score, penalties football.Time, home bool) (int, int) {
+	if home {
+		return score.HomeTeam - penalties.HomeTeam, score.AwayTeam - penalties.AwayTeam
+	}
+	return score.AwayTeam - penalties.AwayTeam, score.HomeTeam - penalties.HomeTeam
+}
+
+func isFinished(match football.Match) bool {
+	return match.Status == string(football.StatusFinished) &&
+		match.Score != nil && match.HomeTeam != nil && match.AwayTeam != nil
+}
+
+func kickoff(match football.Match) time.Time {
+	date, _ := time.Parse(time.RFC3339, match.UtcDate)
+	return date
+}
+
+func perMatch(total, played int) float64 {
+	if played == 0 {
+		return 0
+	}
+	return float64(total) / float64(played)
+}
+
+func nonNegative(n int) int {
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
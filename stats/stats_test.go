@@ -0,0 +1,101 @@
+package stats
+
+import (
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+const flamengo = 1783
+
+func match(date string, homeID, awayID int, half, full football.Time) football.Match {
+	return football.Match{
+		UtcDate:  date,
+		Status:   string(football.StatusFinished),
+		HomeTeam: &football.Team{ID: homeID},
+		AwayTeam: &football.Team{ID: awayID},
+		Score:    &football.Score{Duration: "REGULAR", HalfTime: half, FullTime: full},
+	}
+}
+
+func fixtures() []football.Match {
+	scheduled := match("2021-09-20T22:00:00Z", flamengo, 1, football.Time{}, football.Time{})
+	scheduled.Status = string(football.StatusScheduled)
+
+	return []football.Match{
+		// Listed out of order on purpose.
+		match("2021-09-12T22:00:00Z", 5, flamengo, football.Time{HomeTeam: 1}, football.Time{HomeTeam: 2, AwayTeam: 1}),
+		match("2021-08-29T22:00:00Z", flamengo, 2, football.Time{HomeTeam: 1}, football.Time{HomeTeam: 3}),
+		match("2021-09-01T22:00:00Z", 3, flamengo, football.Time{AwayTeam: 1}, football.Time{AwayTeam: 2}),
+		match("2021-09-05T22:00:00Z", flamengo, 4, football.Time{}, football.Time{HomeTeam: 1, AwayTeam: 1}),
+		match("2021-09-15T22:00:00Z", 7, 8, football.Time{}, football.Time{HomeTeam: 1}),
+		scheduled,
+	}
+}
+
+func TestForTeam(t *testing.T) {
+	stats := ForTeam(flamengo, fixtures())
+
+	assert.Equal(t, []Result{Win, Win, Draw, Loss}, stats.Results)
+	assert.Equal(t, "WWDL", stats.Form(10))
+	assert.Equal(t, "DL", stats.Form(2))
+
+	assert.Equal(t, Split{
+		Played:                 4,
+		Won:                    2,
+		Draw:                   1,
+		Lost:                   1,
+		Points:                 7,
+		GoalsFor:               7,
+		GoalsAgainst:           3,
+		CleanSheets:            2,
+		FirstHalfGoalsFor:      2,
+		SecondHalfGoalsFor:     5,
+		FirstHalfGoalsAgainst:  1,
+		SecondHalfGoalsAgainst: 2,
+	}, stats.Overall)
+
+	assert.Equal(t, 2, stats.Home.Played)
+	assert.Equal(t, 4, stats.Home.GoalsFor)
+	assert.Equal(t, 2, stats.Away.Played)
+	assert.Equal(t, 1, stats.Away.CleanSheets)
+
+	assert.Equal(t, 1.75, stats.Overall.PointsPerGame())
+	assert.Equal(t, 1.75, stats.Overall.GoalsForPerMatch())
+	assert.Equal(t, 0.75, stats.Overall.GoalsAgainstPerMatch())
+}
+
+func TestForTeam_Streaks(t *testing.T) {
+	stats := ForTeam(flamengo, fixtures())
+
+	assert.Equal(t, Streak{Result: Loss, Length: 1}, stats.CurrentStreak)
+	assert.Equal(t, 2, stats.LongestWinStreak)
+	assert.Equal(t, 1, stats.LongestLossStreak)
+	assert.Equal(t, 3, stats.LongestUnbeaten)
+}
+
+func TestTeamStats_Last(t *testing.T) {
+	stats := ForTeam(flamengo, fixtures()).Last(2)
+
+	assert.Equal(t, "DL", stats.Form(5))
+	assert.Equal(t, 2, stats.Overall.Played)
+	assert.Equal(t, 0.5, stats.Overall.PointsPerGame())
+}
+
+func TestForTeam_ExtraTime(t *testing.T) {
+	m := match("2021-09-12T22:00:00Z", flamengo, 2, football.Time{HomeTeam: 1}, football.Time{HomeTeam: 5, AwayTeam: 4})
+	m.Score.Duration = "PENALTY_SHOOTOUT"
+	m.Score.Winner = "HOME_TEAM"
+	m.Score.ExtraTime = football.Time{HomeTeam: 1}
+	m.Score.Penalties = football.Time{HomeTeam: 3, AwayTeam: 2}
+
+	stats := ForTeam(flamengo, []football.Match{m})
+
+	assert.Equal(t, 2, stats.Overall.GoalsFor)
+	assert.Equal(t, 2, stats.Overall.GoalsAgainst)
+	assert.Equal(t, 1, stats.Overall.FirstHalfGoalsFor)
+	assert.Equal(t, 0, stats.Overall.SecondHalfGoalsFor)
+	assert.Equal(t, 2, stats.Overall.SecondHalfGoalsAgainst)
+	assert.Equal(t, "D", stats.Form(1))
+}
@@ -0,0 +1,42 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Checkpoint returns the time stored under key, and false when it was never
+// set. Checkpoints let long running jobs record their progress.
+func (s *Store) Checkpoint(ctx context.Context, key string) (time.Time, bool, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx, "SELECT value FROM checkpoints WHERE key = ?", key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	return t, true, nil
+}
+
+// SetCheckpoint stores t under key.
+func (s *Store) SetCheckpoint(ctx context.Context, key string, t time.Time) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO checkpoints (key, value) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`,
+		key, t.UTC().Format(time.RFC3339Nano))
+	return err
+}
+
+// DeleteCheckpoint removes the checkpoint stored under key.
+func (s *Store) DeleteCheckpoint(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM checkpoints WHERE key = ?", key)
+	return err
+}
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpoints(t *testing.T) {
+	s := testStore(t)
+	ctx := context.Background()
+
+	_, ok, err := s.Checkpoint(ctx, "teams:2013")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	at := time.Date(2021, 8, 1, 12, 30, 0, 0, time.UTC)
+	assert.Nil(t, s.SetCheckpoint(ctx, "teams:2013", at))
+	assert.Nil(t, s.SetCheckpoint(ctx, "teams:2013", at.Add(time.Hour)))
+
+	stored, ok, err := s.Checkpoint(ctx, "teams:2013")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, at.Add(time.Hour), stored)
+
+	assert.Nil(t, s.DeleteCheckpoint(ctx, "teams:2013"))
+	_, ok, err = s.Checkpoint(ctx, "teams:2013")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,524 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// MatchQuery narrows the matches returned by Store.Matches. Zero fields are
+// ignored.
+type MatchQuery struct {
+	CompetitionID int
+	SeasonID      int
+	TeamID        int
+	Status        football.Status
+	// DateFrom and DateTo bound the kick-off date, as YYYY-MM-DD, inclusive.
+	DateFrom string
+	DateTo   string
+}
+
+// Area takes an area ID and returns the stored area.
+func (s *Store) Area(ctx context.Context, id int) (*football.Area, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, name, country_code, ensign_url, parent_area_id, parent_area
+		FROM areas WHERE id = ?`, id)
+
+	area, err := scanArea(row)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return &area, nil
+}
+
+// Competition takes a competition ID and returns the stored competition
+// with its area, current season and seasons.
+func (s *Store) Competition(ctx context.Context, id int) (*football.Competition, error) {
+	competitions, err := s.competitions(ctx, "WHERE c.id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(competitions) == 0 {
+		return nil, ErrNotFound
+	}
+
+	competition := competitions[0]
+	competition.Seasons, err = s.seasons(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, season := range competition.Seasons {
+		if season.ID == competition.CurrentSeason.ID {
+			competition.CurrentSeason = season
+		}
+	}
+
+	return &competition, nil
+}
+
+// Competitions returns the stored competitions ordered by ID, without
+// their seasons.
+func (s *Store) Competitions(ctx context.Context) ([]football.Competition, error) {
+	return s.competitions(ctx, "")
+}
+
+// Team takes a team ID and returns the stored team with its area and squad.
+func (s *Store) Team(ctx context.Context, id int) (*football.Team, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, area_id, name, short_name, tla, crest_url, address, phone, website, email, founded,
+			club_colors, venue, last_updated
+		FROM teams WHERE id = ?`, id)
+
+	var (
+		team   football.Team
+		areaID sql.NullInt64
+		fields [12]sql.NullString
+		found  sql.NullInt64
+	)
+	err := row.Scan(&team.ID, &areaID, &fields[0], &fields[1], &fields[2], &fields[3], &fields[4], &fields[5],
+		&fields[6], &fields[7], &found, &fields[8], &fields[9], &fields[10])
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	team.Name, team.ShortName, team.Tla, team.CrestURL = fields[0].String, fields[1].String, fields[2].String, fields[3].String
+	team.Address, team.Phone, team.Website, team.Email = fields[4].String, fields[5].String, fields[6].String, fields[7].String
+	team.ClubColors, team.Venue, team.LastUpdated = fields[8].String, fields[9].String, fields[10].String
+	team.Founded = int(found.Int64)
+
+	if areaID.Valid {
+		team.Area, err = s.Area(ctx, int(areaID.Int64))
+		if err != nil && err != ErrNotFound {
+			return nil, err
+		}
+	}
+
+	squad, err := s.Squad(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(squad) > 0 {
+		team.Squad = &squad
+	}
+
+	return &team, nil
+}
+
+// Squad takes a team ID and returns the players in its stored squad,
+// ordered by name.
+func (s *Store) Squad(ctx context.Context, teamID int) ([]football.Player, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+playerColumns+`, s.role
+		FROM squads s JOIN players p ON p.id = s.player_id
+		WHERE s.team_id = ?
+		ORDER BY p.name, p.id`, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	squad := []football.Player{}
+	for rows.Next() {
+		var role sql.NullString
+		player, err := scanPlayer(rows, &role)
+		if err != nil {
+			return nil, err
+		}
+		player.Role = role.String
+		squad = append(squad, player)
+	}
+
+	return squad, rows.Err()
+}
+
+// Player takes a player ID and returns the stored player.
+func (s *Store) Player(ctx context.Context, id int64) (*football.Player, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+playerColumns+" FROM players p WHERE p.id = ?", id)
+
+	player, err := scanPlayer(row)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return &player, nil
+}
+
+// Match takes a match ID and returns the stored match with its goals,
+// bookings and substitutions.
+func (s *Store) Match(ctx context.Context, id int) (*football.Match, error) {
+	matches, err := s.matches(ctx, "WHERE m.id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matches) == 0 {
+		return nil, ErrNotFound
+	}
+
+	match := matches[0]
+	if match.Goals, err = s.goals(ctx, id); err != nil {
+		return nil, err
+	}
+	if match.Bookings, err = s.bookings(ctx, id); err != nil {
+		return nil, err
+	}
+	if match.Substitutions, err = s.substitutions(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return &match, nil
+}
+
+// Matches returns the stored matches matching query, ordered by kick-off,
+// without their goals, bookings and substitutions.
+func (s *Store) Matches(ctx context.Context, query *MatchQuery) ([]football.Match, error) {
+	if query == nil {
+		query = &MatchQuery{}
+	}
+
+	conditions := []string{}
+	args := []interface{}{}
+
+	if query.CompetitionID != 0 {
+		conditions = append(conditions, "m.competition_id = ?")
+		args = append(args, query.CompetitionID)
+	}
+	if query.SeasonID != 0 {
+		conditions = append(conditions, "m.season_id = ?")
+		args = append(args, query.SeasonID)
+	}
+	if query.TeamID != 0 {
+		conditions = append(conditions, "(m.home_team_id = ? OR m.away_team_id = ?)")
+		args = append(args, query.TeamID, query.TeamID)
+	}
+	if query.Status != "" {
+		conditions = append(conditions, "m.status = ?")
+		args = append(args, string(query.Status))
+	}
+	if query.DateFrom != "" {
+		conditions = append(conditions, "substr(m.utc_date, 1, 10) >= ?")
+		args = append(args, query.DateFrom)
+	}
+	if query.DateTo != "" {
+		conditions = append(conditions, "substr(m.utc_date, 1, 10) <= ?")
+		args = append(args, query.DateTo)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return s.matches(ctx, where, args...)
+}
+
+const playerColumns = `p.id, p.name, p.first_name, p.last_name, p.date_of_birth, p.country_of_birth,
+	p.nationality, p.position, p.shirt_number, p.last_updated`
+
+// scanner is implemented by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanArea(row scanner) (football.Area, error) {
+	var (
+		area     football.Area
+		fields   [4]sql.NullString
+		parentID sql.NullInt64
+	)
+
+	err := row.Scan(&area.ID, &fields[0], &fields[1], &fields[2], &parentID, &fields[3])
+	area.Name, area.CountryCode, area.EnsignUrl, area.ParentArea = fields[0].String, fields[1].String, fields[2].String, fields[3].String
+	area.ParentAreaID = int(parentID.Int64)
+
+	return area, err
+}
+
+func scanPlayer(row scanner, extra ...interface{}) (football.Player, error) {
+	var (
+		player football.Player
+		fields [8]sql.NullString
+		number sql.NullInt64
+	)
+
+	dest := []interface{}{&player.ID, &fields[0], &fields[1], &fields[2], &fields[3], &fields[4], &fields[5],
+		&fields[6], &number, &fields[7]}
+	err := row.Scan(append(dest, extra...)...)
+
+	player.Name, player.FirstName, player.LastName, player.DateOfBirth = fields[0].String, fields[1].String, fields[2].String, fields[3].String
+	player.CountryOfBirth, player.Nationality, player.Position, player.LastUpdated = fields[4].String, fields[5].String, fields[6].String, fields[7].String
+	player.ShirtNumber = int(number.Int64)
+
+	return player, err
+}
+
+func (s *Store) competitions(ctx context.Context, where string, args ...interface{}) ([]football.Competition, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT c.id, c.name, c.code, c.plan, c.current_season_id, c.number_of_available_seasons, c.last_updated,
+			COALESCE(a.id, 0), a.name, a.country_code, a.ensign_url, a.parent_area_id, a.parent_area
+		FROM competitions c LEFT JOIN areas a ON a.id = c.area_id
+		`+where+`
+		ORDER BY c.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	competitions := []football.Competition{}
+	for rows.Next() {
+		var (
+			competition football.Competition
+			fields      [4]sql.NullString
+			seasonID    sql.NullInt64
+			seasons     sql.NullInt64
+			areaFields  [4]sql.NullString
+			parentID    sql.NullInt64
+		)
+
+		err := rows.Scan(&competition.ID, &fields[0], &fields[1], &fields[2], &seasonID, &seasons, &fields[3],
+			&competition.Area.ID, &areaFields[0], &areaFields[1], &areaFields[2], &parentID, &areaFields[3])
+		if err != nil {
+			return nil, err
+		}
+
+		competition.Name, competition.Code, competition.Plan, competition.LastUpdated = fields[0].String, fields[1].String, fields[2].String, fields[3].String
+		competition.CurrentSeason.ID = int(seasonID.Int64)
+		competition.NumberOfAvailableSeasons = int(seasons.Int64)
+		competition.Area.Name, competition.Area.CountryCode = areaFields[0].String, areaFields[1].String
+		competition.Area.EnsignUrl, competition.Area.ParentArea = areaFields[2].String, areaFields[3].String
+		competition.Area.ParentAreaID = int(parentID.Int64)
+
+		competitions = append(competitions, competition)
+	}
+
+	return competitions, rows.Err()
+}
+
+func (s *Store) seasons(ctx context.Context, competitionID int) ([]football.Season, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT s.id, s.start_date, s.end_date, s.current_matchday, COALESCE(t.id, 0), t.name, t.short_name, t.tla, t.crest_url
+		FROM seasons s LEFT JOIN teams t ON t.id = s.winner_id
+		WHERE s.competition_id = ?
+		ORDER BY s.start_date DESC, s.id DESC`, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seasons := []football.Season{}
+	for rows.Next() {
+		var (
+			season   football.Season
+			fields   [6]sql.NullString
+			matchday sql.NullInt64
+		)
+
+		err := rows.Scan(&season.ID, &fields[0], &fields[1], &matchday, &season.Winner.ID, &fields[2], &fields[3],
+			&fields[4], &fields[5])
+		if err != nil {
+			return nil, err
+		}
+
+		season.StartDate, season.EndDate = fields[0].String, fields[1].String
+		season.CurrentMatchday = int(matchday.Int64)
+		season.Winner.Name, season.Winner.ShortName, season.Winner.TLa, season.Winner.CrestURL = fields[2].String, fields[3].String, fields[4].String, fields[5].String
+
+		seasons = append(seasons, season)
+	}
+
+	return seasons, rows.Err()
+}
+
+func (s *Store) matches(ctx context.Context, where string, args ...interface{}) ([]football.Match, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT m.id, COALESCE(c.id, 0), c.name, c.code, COALESCE(s.id, 0), s.start_date, s.end_date, s.current_matchday,
+			m.utc_date, m.status, m.attendance, m.venue, m.matchday, m.stage, m.group_name, m.last_updated,
+			COALESCE(h.id, 0), h.name, COALESCE(a.id, 0), a.name,
+			m.winner, m.duration, m.full_time_home, m.full_time_away, m.half_time_home, m.half_time_away,
+			m.extra_time_home, m.extra_time_away, m.penalties_home, m.penalties_away
+		FROM matches m
+		LEFT JOIN competitions c ON c.id = m.competition_id
+		LEFT JOIN seasons s ON s.id = m.season_id
+		LEFT JOIN teams h ON h.id = m.home_team_id
+		LEFT JOIN teams a ON a.id = m.away_team_id
+		`+where+`
+		ORDER BY m.utc_date, m.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matches := []football.Match{}
+	for rows.Next() {
+		var (
+			match       football.Match
+			competition football.Competition
+			season      football.Season
+			home, away  football.Team
+			score       football.Score
+			fields      [14]sql.NullString
+			numbers     [3]sql.NullInt64
+			goals       [8]sql.NullInt64
+		)
+
+		err := rows.Scan(&match.ID, &competition.ID, &fields[0], &fields[1], &season.ID, &fields[2], &fields[3],
+			&numbers[0], &fields[4], &fields[5], &numbers[1], &fields[6], &numbers[2], &fields[7], &fields[8],
+			&fields[9], &home.ID, &fields[10], &away.ID, &fields[11], &fields[12], &fields[13],
+			&goals[0], &goals[1], &goals[2], &goals[3], &goals[4], &goals[5], &goals[6], &goals[7])
+		if err != nil {
+			return nil, err
+		}
+
+		competition.Name, competition.Code = fields[0].String, fields[1].String
+		season.StartDate, season.EndDate, season.CurrentMatchday = fields[2].String, fields[3].String, int(numbers[0].Int64)
+		match.UtcDate, match.Status, match.Attendance, match.Venue = fields[4].String, fields[5].String, int(numbers[1].Int64), fields[6].String
+		match.Matchday, match.Stage, match.Group, match.LastUpdated = int(numbers[2].Int64), fields[7].String, fields[8].String, fields[9].String
+		home.Name, away.Name = fields[10].String, fields[11].String
+
+		score.Winner, score.Duration = fields[12].String, fields[13].String
+		score.FullTime = football.Time{HomeTeam: int(goals[0].Int64), AwayTeam: int(goals[1].Int64)}
+		score.HalfTime = football.Time{HomeTeam: int(goals[2].Int64), AwayTeam: int(goals[3].Int64)}
+		score.ExtraTime = football.Time{HomeTeam: int(goals[4].Int64), AwayTeam: int(goals[5].Int64)}
+		score.Penalties = football.Time{HomeTeam: int(goals[6].Int64), AwayTeam: int(goals[7].Int64)}
+		match.Score = &score
+
+		if competition.ID != 0 {
+			match.Competition = &competition
+		}
+		if season.ID != 0 {
+			match.Season = &season
+		}
+		if home.ID != 0 {
+			match.HomeTeam = &home
+		}
+		if away.ID != 0 {
+			match.AwayTeam = &away
+		}
+
+		matches = append(matches, match)
+	}
+
+	return matches, rows.Err()
+}
+
+func (s *Store) goals(ctx context.Context, matchID int) ([]football.Goals, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT g.minute, g.extra_time, g.type, COALESCE(t.id, 0), t.name,
+			COALESCE(sc.id, 0), sc.name, COALESCE(a.id, 0), a.name
+		FROM goals g
+		LEFT JOIN teams t ON t.id = g.team_id
+		LEFT JOIN players sc ON sc.id = g.scorer_id
+		LEFT JOIN players a ON a.id = g.assist_id
+		WHERE g.match_id = ?
+		ORDER BY g.seq`, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	goals := []football.Goals{}
+	for rows.Next() {
+		var (
+			goal      football.Goals
+			extraTime sql.NullInt64
+			fields    [4]sql.NullString
+		)
+
+		err := rows.Scan(&goal.Minute, &extraTime, &fields[0], &goal.Team.ID, &fields[1], &goal.Scorer.ID,
+			&fields[2], &goal.Assist.ID, &fields[3])
+		if err != nil {
+			return nil, err
+		}
+
+		if extraTime.Valid {
+			goal.ExtraTime = float64(extraTime.Int64)
+		}
+		goal.Type, goal.Team.Name, goal.Scorer.Name, goal.Assist.Name = fields[0].String, fields[1].String, fields[2].String, fields[3].String
+
+		goals = append(goals, goal)
+	}
+
+	return goals, rows.Err()
+}
+
+func (s *Store) bookings(ctx context.Context, matchID int) ([]football.Bookings, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT b.minute, COALESCE(t.id, 0), t.name, COALESCE(p.id, 0), p.name, b.card
+		FROM bookings b
+		LEFT JOIN teams t ON t.id = b.team_id
+		LEFT JOIN players p ON p.id = b.player_id
+		WHERE b.match_id = ?
+		ORDER BY b.seq`, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bookings := []football.Bookings{}
+	for rows.Next() {
+		var (
+			booking football.Bookings
+			fields  [3]sql.NullString
+		)
+
+		err := rows.Scan(&booking.Minute, &booking.Team.ID, &fields[0], &booking.Player.ID, &fields[1], &fields[2])
+		if err != nil {
+			return nil, err
+		}
+
+		booking.Team.Name, booking.Player.Name, booking.Card = fields[0].String, fields[1].String, fields[2].String
+
+		bookings = append(bookings, booking)
+	}
+
+	return bookings, rows.Err()
+}
+
+func (s *Store) substitutions(ctx context.Context, matchID int) ([]football.Substitutions, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT s.minute, COALESCE(t.id, 0), t.name, COALESCE(po.id, 0), po.name, COALESCE(pi.id, 0), pi.name
+		FROM substitutions s
+		LEFT JOIN teams t ON t.id = s.team_id
+		LEFT JOIN players po ON po.id = s.player_out_id
+		LEFT JOIN players pi ON pi.id = s.player_in_id
+		WHERE s.match_id = ?
+		ORDER BY s.seq`, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	substitutions := []football.Substitutions{}
+	for rows.Next() {
+		var (
+			substitution football.Substitutions
+			fields       [3]sql.NullString
+		)
+
+		err := rows.Scan(&substitution.Minute, &substitution.Team.ID, &fields[0], &substitution.PlayerOut.ID,
+			&fields[1], &substitution.PlayerIn.ID, &fields[2])
+		if err != nil {
+			return nil, err
+		}
+
+		substitution.Team.Name, substitution.PlayerOut.Name, substitution.PlayerIn.Name = fields[0].String, fields[1].String, fields[2].String
+
+		substitutions = append(substitutions, substitution)
+	}
+
+	return substitutions, rows.Err()
+}
+
+// notFound maps sql.ErrNoRows to ErrNotFound.
+func notFound(err error) error {
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatches(t *testing.T) {
+	s := testStore(t)
+	ctx := context.Background()
+
+	matches := []football.Match{
+		{
+			ID:          1,
+			Competition: &football.Competition{ID: 2013},
+			Season:      &football.Season{ID: 734},
+			UtcDate:     "2021-06-20T18:00:00Z",
+			Status:      string(football.StatusFinished),
+			HomeTeam:    &football.Team{ID: 10},
+			AwayTeam:    &football.Team{ID: 20},
+		},
+		{
+			ID:          2,
+			Competition: &football.Competition{ID: 2013},
+			Season:      &football.Season{ID: 734},
+			UtcDate:     "2021-06-27T18:00:00Z",
+			Status:      string(football.StatusScheduled),
+			HomeTeam:    &football.Team{ID: 20},
+			AwayTeam:    &football.Team{ID: 30},
+		},
+		{
+			ID:          3,
+			Competition: &football.Competition{ID: 2021},
+			Season:      &football.Season{ID: 733},
+			UtcDate:     "2021-06-21T18:00:00Z",
+			Status:      string(football.StatusFinished),
+			HomeTeam:    &football.Team{ID: 40},
+			AwayTeam:    &football.Team{ID: 10},
+		},
+	}
+	assert.Nil(t, s.UpsertMatches(ctx, matches))
+
+	tests := []struct {
+		name  string
+		query *MatchQuery
+		ids   []int
+	}{
+		{"all", nil, []int{1, 3, 2}},
+		{"competition", &MatchQuery{CompetitionID: 2013}, []int{1, 2}},
+		{"season", &MatchQuery{SeasonID: 733}, []int{3}},
+		{"team", &MatchQuery{TeamID: 10}, []int{1, 3}},
+		{"status", &MatchQuery{Status: football.StatusScheduled}, []int{2}},
+		{"dates", &MatchQuery{DateFrom: "2021-06-21", DateTo: "2021-06-27"}, []int{3, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			found, err := s.Matches(ctx, test.query)
+			assert.Nil(t, err)
+
+			ids := []int{}
+			for _, match := range found {
+				ids = append(ids, match.ID)
+			}
+			assert.Equal(t, test.ids, ids)
+		})
+	}
+}
+
+func TestQueries_NotFound(t *testing.T) {
+	s := testStore(t)
+	ctx := context.Background()
+
+	_, err := s.Match(ctx, 1)
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = s.Team(ctx, 1)
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = s.Competition(ctx, 1)
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = s.Player(ctx, 1)
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -0,0 +1,142 @@
+package store
+
+// migrations holds the schema changes in the order they are applied. New
+// migrations must be appended; applied ones must never change.
+var migrations = []string{
+	`
+	CREATE TABLE areas (
+		id             INTEGER PRIMARY KEY,
+		name           TEXT,
+		country_code   TEXT,
+		ensign_url     TEXT,
+		parent_area_id INTEGER,
+		parent_area    TEXT
+	);
+
+	CREATE TABLE competitions (
+		id                          INTEGER PRIMARY KEY,
+		area_id                     INTEGER REFERENCES areas (id),
+		name                        TEXT,
+		code                        TEXT,
+		plan                        TEXT,
+		current_season_id           INTEGER,
+		number_of_available_seasons INTEGER,
+		last_updated                TEXT
+	);
+
+	CREATE TABLE teams (
+		id           INTEGER PRIMARY KEY,
+		area_id      INTEGER REFERENCES areas (id),
+		name         TEXT,
+		short_name   TEXT,
+		tla          TEXT,
+		crest_url    TEXT,
+		address      TEXT,
+		phone        TEXT,
+		website      TEXT,
+		email        TEXT,
+		founded      INTEGER,
+		club_colors  TEXT,
+		venue        TEXT,
+		last_updated TEXT
+	);
+
+	CREATE TABLE seasons (
+		id               INTEGER PRIMARY KEY,
+		competition_id   INTEGER REFERENCES competitions (id),
+		start_date       TEXT,
+		end_date         TEXT,
+		current_matchday INTEGER,
+		winner_id        INTEGER REFERENCES teams (id)
+	);
+
+	CREATE TABLE players (
+		id               INTEGER PRIMARY KEY,
+		name             TEXT,
+		first_name       TEXT,
+		last_name        TEXT,
+		date_of_birth    TEXT,
+		country_of_birth TEXT,
+		nationality      TEXT,
+		position         TEXT,
+		shirt_number     INTEGER,
+		last_updated     TEXT
+	);
+
+	CREATE TABLE squads (
+		team_id   INTEGER NOT NULL REFERENCES teams (id),
+		player_id INTEGER NOT NULL REFERENCES players (id),
+		role      TEXT,
+		PRIMARY KEY (team_id, player_id)
+	);
+
+	CREATE TABLE matches (
+		id              INTEGER PRIMARY KEY,
+		competition_id  INTEGER REFERENCES competitions (id),
+		season_id       INTEGER REFERENCES seasons (id),
+		utc_date        TEXT,
+		status          TEXT,
+		attendance      INTEGER,
+		venue           TEXT,
+		matchday        INTEGER,
+		stage           TEXT,
+		group_name      TEXT,
+		last_updated    TEXT,
+		home_team_id    INTEGER REFERENCES teams (id),
+		away_team_id    INTEGER REFERENCES teams (id),
+		winner          TEXT,
+		duration        TEXT,
+		full_time_home  INTEGER,
+		full_time_away  INTEGER,
+		half_time_home  INTEGER,
+		half_time_away  INTEGER,
+		extra_time_home INTEGER,
+		extra_time_away INTEGER,
+		penalties_home  INTEGER,
+		penalties_away  INTEGER
+	);
+
+	CREATE INDEX matches_competition_season ON matches (competition_id, season_id);
+	CREATE INDEX matches_home_team ON matches (home_team_id);
+	CREATE INDEX matches_away_team ON matches (away_team_id);
+	CREATE INDEX matches_utc_date ON matches (utc_date);
+
+	CREATE TABLE goals (
+		match_id   INTEGER NOT NULL REFERENCES matches (id),
+		seq        INTEGER NOT NULL,
+		minute     INTEGER,
+		extra_time INTEGER,
+		type       TEXT,
+		team_id    INTEGER REFERENCES teams (id),
+		scorer_id  INTEGER REFERENCES players (id),
+		assist_id  INTEGER REFERENCES players (id),
+		PRIMARY KEY (match_id, seq)
+	);
+
+	CREATE TABLE bookings (
+		match_id  INTEGER NOT NULL REFERENCES matches (id),
+		seq       INTEGER NOT NULL,
+		minute    INTEGER,
+		team_id   INTEGER REFERENCES teams (id),
+		player_id INTEGER REFERENCES players (id),
+		card      TEXT,
+		PRIMARY KEY (match_id, seq)
+	);
+
+	CREATE TABLE substitutions (
+		match_id      INTEGER NOT NULL REFERENCES matches (id),
+		seq           INTEGER NOT NULL,
+		minute        INTEGER,
+		team_id       INTEGER REFERENCES teams (id),
+		player_out_id INTEGER REFERENCES players (id),
+		player_in_id  INTEGER REFERENCES players (id),
+		PRIMARY KEY (match_id, seq)
+	);
+	`,
+	`
+	CREATE TABLE checkpoints (
+		key   TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	);
+	`,
+}
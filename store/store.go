@@ -0,0 +1,126 @@
+// Package store persists areas, competitions, seasons, teams, squads and
+// matches in a normalized SQLite database.
+//
+// Upserts accept the structs returned by the services and merge them with
+// what is stored: fields that are empty in the struct keep their stored
+// value, so partial structs, such as the teams embedded in a match, never
+// erase data fetched before.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	_ "modernc.org/sqlite" // Registers the in-process "sqlite" driver.
+)
+
+// ErrNotFound is returned by queries when no row matches.
+var ErrNotFound = errors.New("Not found")
+
+// Store reads and writes SDK structs to a database.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the SQLite database at path, creating it if needed, and
+// applies the pending migrations. Use ":memory:" for an in-memory database.
+func Open(path string) (*Store, error) {
+	dsn := path
+	if strings.Contains(dsn, "?") {
+		dsn += "&_pragma=foreign_keys(1)"
+	} else {
+		dsn += "?_pragma=foreign_keys(1)"
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every connection to an in-memory database sees a different database.
+	if path == ":memory:" {
+		db.SetMaxOpenConns(1)
+	}
+
+	s, err := New(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// New returns a Store using db, which must be a SQLite database, and
+// applies the pending migrations.
+func New(db *sql.DB) (*Store, error) {
+	s := &Store{db: db}
+	if err := s.Migrate(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// DB returns the underlying database, for queries not covered by Store.
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Version returns the number of migrations applied to the database.
+func (s *Store) Version(ctx context.Context) (int, error) {
+	version := 0
+	err := s.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	return version, err
+}
+
+// Migrate applies the migrations that were not applied yet.
+func (s *Store) Migrate(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`)
+	if err != nil {
+		return err
+	}
+
+	version, err := s.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := version; i < len(migrations); i++ {
+		err := s.transaction(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, migrations[i]); err != nil {
+				return fmt.Errorf("migration %d: %v", i+1, err)
+			}
+			_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES (?)", i+1)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// transaction runs fn in a transaction, committing it when fn succeeds.
+func (s *Store) transaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
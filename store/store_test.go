@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testStore(t *testing.T) *Store {
+	s, err := Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestOpen_AppliesMigrations(t *testing.T) {
+	s := testStore(t)
+
+	version, err := s.Version(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, len(migrations), version)
+}
+
+func TestMigrate_IsIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "football.db")
+
+	s, err := Open(path)
+	assert.Nil(t, err)
+	assert.Nil(t, s.Close())
+
+	s, err = Open(path)
+	assert.Nil(t, err)
+	defer s.Close()
+
+	assert.Nil(t, s.Migrate(context.Background()))
+	version, err := s.Version(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, len(migrations), version)
+}
+
+func TestOpen_EnablesForeignKeys(t *testing.T) {
+	s := testStore(t)
+
+	_, err := s.DB().Exec("INSERT INTO squads (team_id, player_id) VALUES (1, 1)")
+
+	assert.NotNil(t, err)
+}
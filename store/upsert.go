@@ -0,0 +1,469 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// execer is implemented by *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// UpsertArea inserts the area or merges it into the stored one.
+func (s *Store) UpsertArea(ctx context.Context, area football.Area) error {
+	return upsertArea(ctx, s.db, area)
+}
+
+// UpsertCompetition inserts the competition, its area and its seasons or
+// merges them into the stored ones.
+func (s *Store) UpsertCompetition(ctx context.Context, competition football.Competition) error {
+	return s.transaction(ctx, func(tx *sql.Tx) error {
+		return upsertCompetition(ctx, tx, competition)
+	})
+}
+
+// UpsertTeam inserts the team and its area or merges them into the stored
+// ones. When the team carries a squad, the stored squad is replaced by it.
+func (s *Store) UpsertTeam(ctx context.Context, team football.Team) error {
+	return s.transaction(ctx, func(tx *sql.Tx) error {
+		return upsertTeam(ctx, tx, team)
+	})
+}
+
+// UpsertPlayer inserts the player or merges it into the stored one.
+func (s *Store) UpsertPlayer(ctx context.Context, player football.Player) error {
+	return upsertPlayer(ctx, s.db, player)
+}
+
+// UpsertMatch inserts the match and the competition, season, teams and
+// players it references, or merges them into the stored ones. Goals,
+// bookings and substitutions are replaced when the match carries them.
+func (s *Store) UpsertMatch(ctx context.Context, match football.Match) error {
+	return s.UpsertMatches(ctx, []football.Match{match})
+}
+
+// UpsertMatches upserts the matches in a single transaction.
+func (s *Store) UpsertMatches(ctx context.Context, matches []football.Match) error {
+	return s.transaction(ctx, func(tx *sql.Tx) error {
+		for _, match := range matches {
+			if err := upsertMatch(ctx, tx, match); err != nil {
+				return fmt.Errorf("match %d: %v", match.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+func upsertArea(ctx context.Context, db execer, area football.Area) error {
+	if area.ID == 0 {
+		return nil
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO areas (id, name, country_code, ensign_url, parent_area_id, parent_area)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			name = COALESCE(excluded.name, name),
+			country_code = COALESCE(excluded.country_code, country_code),
+			ensign_url = COALESCE(excluded.ensign_url, ensign_url),
+			parent_area_id = COALESCE(excluded.parent_area_id, parent_area_id),
+			parent_area = COALESCE(excluded.parent_area, parent_area)`,
+		area.ID, nullString(area.Name), nullString(area.CountryCode), nullString(area.EnsignUrl),
+		nullInt(int64(area.ParentAreaID)), nullString(area.ParentArea))
+	return err
+}
+
+func upsertCompetition(ctx context.Context, db execer, competition football.Competition) error {
+	if competition.ID == 0 {
+		return nil
+	}
+
+	if err := upsertArea(ctx, db, competition.Area); err != nil {
+		return err
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO competitions (id, area_id, name, code, plan, current_season_id, number_of_available_seasons, last_updated)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			area_id = COALESCE(excluded.area_id, area_id),
+			name = COALESCE(excluded.name, name),
+			code = COALESCE(excluded.code, code),
+			plan = COALESCE(excluded.plan, plan),
+			current_season_id = COALESCE(excluded.current_season_id, current_season_id),
+			number_of_available_seasons = COALESCE(excluded.number_of_available_seasons, number_of_available_seasons),
+			last_updated = COALESCE(excluded.last_updated, last_updated)`,
+		competition.ID, nullInt(int64(competition.Area.ID)), nullString(competition.Name),
+		nullString(competition.Code), nullString(competition.Plan), nullInt(int64(competition.CurrentSeason.ID)),
+		nullInt(int64(competition.NumberOfAvailableSeasons)), nullString(competition.LastUpdated))
+	if err != nil {
+		return err
+	}
+
+	if err := upsertSeason(ctx, db, competition.ID, competition.CurrentSeason); err != nil {
+		return err
+	}
+
+	for _, season := range competition.Seasons {
+		if err := upsertSeason(ctx, db, competition.ID, season); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func upsertSeason(ctx context.Context, db execer, competitionID int, season football.Season) error {
+	if season.ID == 0 {
+		return nil
+	}
+
+	if season.Winner.ID != 0 {
+		winner := football.Team{
+			ID:        season.Winner.ID,
+			Name:      season.Winner.Name,
+			ShortName: season.Winner.ShortName,
+			Tla:       season.Winner.TLa,
+			CrestURL:  season.Winner.CrestURL,
+		}
+		if err := upsertTeamRow(ctx, db, winner); err != nil {
+			return err
+		}
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO seasons (id, competition_id, start_date, end_date, current_matchday, winner_id)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			competition_id = COALESCE(excluded.competition_id, competition_id),
+			start_date = COALESCE(excluded.start_date, start_date),
+			end_date = COALESCE(excluded.end_date, end_date),
+			current_matchday = COALESCE(excluded.current_matchday, current_matchday),
+			winner_id = COALESCE(excluded.winner_id, winner_id)`,
+		season.ID, nullInt(int64(competitionID)), nullString(season.StartDate), nullString(season.EndDate),
+		nullInt(int64(season.CurrentMatchday)), nullInt(int64(season.Winner.ID)))
+	return err
+}
+
+func upsertTeam(ctx context.Context, db execer, team football.Team) error {
+	if team.ID == 0 {
+		return nil
+	}
+
+	if err := upsertTeamRow(ctx, db, team); err != nil {
+		return err
+	}
+
+	if team.Squad == nil {
+		return nil
+	}
+
+	if _, err := db.ExecContext(ctx, "DELETE FROM squads WHERE team_id = ?", team.ID); err != nil {
+		return err
+	}
+
+	for _, player := range *team.Squad {
+		if err := upsertPlayer(ctx, db, player); err != nil {
+			return err
+		}
+
+		_, err := db.ExecContext(ctx, "INSERT OR REPLACE INTO squads (team_id, player_id, role) VALUES (?, ?, ?)",
+			team.ID, player.ID, nullString(player.Role))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upsertTeamRow stores the team without its squad. Teams without ID, such
+// as the undecided teams of knockout fixtures, are skipped.
+func upsertTeamRow(ctx context.Context, db execer, team football.Team) error {
+	if team.ID == 0 {
+		return nil
+	}
+
+	areaID := 0
+	if team.Area != nil {
+		if err := upsertArea(ctx, db, *team.Area); err != nil {
+			return err
+		}
+		areaID = team.Area.ID
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO teams (id, area_id, name, short_name, tla, crest_url, address, phone, website, email, founded, club_colors, venue, last_updated)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			area_id = COALESCE(excluded.area_id, area_id),
+			name = COALESCE(excluded.name, name),
+			short_name = COALESCE(excluded.short_name, short_name),
+			tla = COALESCE(excluded.tla, tla),
+			crest_url = COALESCE(excluded.crest_url, crest_url),
+			address = COALESCE(excluded.address, address),
+			phone = COALESCE(excluded.phone, phone),
+			website = COALESCE(excluded.website, website),
+			email = COALESCE(excluded.email, email),
+			founded = COALESCE(excluded.founded, founded),
+			club_colors = COALESCE(excluded.club_colors, club_colors),
+			venue = COALESCE(excluded.venue, venue),
+			last_updated = COALESCE(excluded.last_updated, last_updated)`,
+		team.ID, nullInt(int64(areaID)), nullString(team.Name), nullString(team.ShortName), nullString(team.Tla),
+		nullString(team.CrestURL), nullString(team.Address), nullString(team.Phone), nullString(team.Website),
+		nullString(team.Email), nullInt(int64(team.Founded)), nullString(team.ClubColors), nullString(team.Venue),
+		nullString(team.LastUpdated))
+	return err
+}
+
+func upsertPlayer(ctx context.Context, db execer, player football.Player) error {
+	if player.ID == 0 {
+		return nil
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO players (id, name, first_name, last_name, date_of_birth, country_of_birth, nationality, position, shirt_number, last_updated)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			name = COALESCE(excluded.name, name),
+			first_name = COALESCE(excluded.first_name, first_name),
+			last_name = COALESCE(excluded.last_name, last_name),
+			date_of_birth = COALESCE(excluded.date_of_birth, date_of_birth),
+			country_of_birth = COALESCE(excluded.country_of_birth, country_of_birth),
+			nationality = COALESCE(excluded.nationality, nationality),
+			position = COALESCE(excluded.position, position),
+			shirt_number = COALESCE(excluded.shirt_number, shirt_number),
+			last_updated = COALESCE(excluded.last_updated, last_updated)`,
+		player.ID, nullString(player.Name), nullString(player.FirstName), nullString(player.LastName),
+		nullString(player.DateOfBirth), nullString(player.CountryOfBirth), nullString(player.Nationality),
+		nullString(player.Position), nullInt(int64(player.ShirtNumber)), nullString(player.LastUpdated))
+	return err
+}
+
+func upsertMatch(ctx context.Context, db execer, match football.Match) error {
+	competitionID, seasonID, homeID, awayID := 0, 0, 0, 0
+
+	if match.Competition != nil {
+		if err := upsertCompetition(ctx, db, *match.Competition); err != nil {
+			return err
+		}
+		competitionID = match.Competition.ID
+	}
+
+	if match.Season != nil {
+		if err := upsertSeason(ctx, db, competitionID, *match.Season); err != nil {
+			return err
+		}
+		seasonID = match.Season.ID
+	}
+
+	if match.HomeTeam != nil {
+		if err := upsertTeamRow(ctx, db, *match.HomeTeam); err != nil {
+			return err
+		}
+		homeID = match.HomeTeam.ID
+	}
+
+	if match.AwayTeam != nil {
+		if err := upsertTeamRow(ctx, db, *match.AwayTeam); err != nil {
+			return err
+		}
+		awayID = match.AwayTeam.ID
+	}
+
+	args := []interface{}{match.ID, nullInt(int64(competitionID)), nullInt(int64(seasonID)), nullString(match.UtcDate),
+		nullString(match.Status), nullInt(int64(match.Attendance)), nullString(match.Venue),
+		nullInt(int64(match.Matchday)), nullString(match.Stage), nullString(match.Group),
+		nullString(match.LastUpdated), nullInt(int64(homeID)), nullInt(int64(awayID))}
+	args = append(args, scoreValues(match)...)
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO matches (id, competition_id, season_id, utc_date, status, attendance, venue, matchday, stage, group_name,
+			last_updated, home_team_id, away_team_id, winner, duration, full_time_home, full_time_away, half_time_home,
+			half_time_away, extra_time_home, extra_time_away, penalties_home, penalties_away)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			competition_id = COALESCE(excluded.competition_id, competition_id),
+			season_id = COALESCE(excluded.season_id, season_id),
+			utc_date = COALESCE(excluded.utc_date, utc_date),
+			status = COALESCE(excluded.status, status),
+			attendance = COALESCE(excluded.attendance, attendance),
+			venue = COALESCE(excluded.venue, venue),
+			matchday = COALESCE(excluded.matchday, matchday),
+			stage = COALESCE(excluded.stage, stage),
+			group_name = COALESCE(excluded.group_name, group_name),
+			last_updated = COALESCE(excluded.last_updated, last_updated),
+			home_team_id = COALESCE(excluded.home_team_id, home_team_id),
+			away_team_id = COALESCE(excluded.away_team_id, away_team_id),
+			winner = COALESCE(excluded.winner, winner),
+			duration = COALESCE(excluded.duration, duration),
+			full_time_home = COALESCE(excluded.full_time_home, full_time_home),
+			full_time_away = COALESCE(excluded.full_time_away, full_time_away),
+			half_time_home = COALESCE(excluded.half_time_home, half_time_home),
+			half_time_away = COALESCE(excluded.half_time_away, half_time_away),
+			extra_time_home = COALESCE(excluded.extra_time_home, extra_time_home),
+			extra_time_away = COALESCE(excluded.extra_time_away, extra_time_away),
+			penalties_home = COALESCE(excluded.penalties_home, penalties_home),
+			penalties_away = COALESCE(excluded.penalties_away, penalties_away)`,
+		args...)
+	if err != nil {
+		return err
+	}
+
+	if match.Goals != nil {
+		if err := replaceGoals(ctx, db, match.ID, match.Goals); err != nil {
+			return err
+		}
+	}
+
+	if match.Bookings != nil {
+		if err := replaceBookings(ctx, db, match.ID, match.Bookings); err != nil {
+			return err
+		}
+	}
+
+	if match.Substitutions != nil {
+		if err := replaceSubstitutions(ctx, db, match.ID, match.Substitutions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func replaceGoals(ctx context.Context, db execer, matchID int, goals []football.Goals) error {
+	if _, err := db.ExecContext(ctx, "DELETE FROM goals WHERE match_id = ?", matchID); err != nil {
+		return err
+	}
+
+	for i, goal := range goals {
+		if err := upsertEventRefs(ctx, db, goal.Team, goal.Scorer, goal.Assist); err != nil {
+			return err
+		}
+
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO goals (match_id, seq, minute, extra_time, type, team_id, scorer_id, assist_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			matchID, i, goal.Minute, nullInt(int64(goal.ExtraMinutes())), nullString(goal.Type),
+			nullInt(int64(goal.Team.ID)), nullInt(goal.Scorer.ID), nullInt(goal.Assist.ID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func replaceBookings(ctx context.Context, db execer, matchID int, bookings []football.Bookings) error {
+	if _, err := db.ExecContext(ctx, "DELETE FROM bookings WHERE match_id = ?", matchID); err != nil {
+		return err
+	}
+
+	for i, booking := range bookings {
+		if err := upsertEventRefs(ctx, db, booking.Team, booking.Player); err != nil {
+			return err
+		}
+
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO bookings (match_id, seq, minute, team_id, player_id, card)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			matchID, i, booking.Minute, nullInt(int64(booking.Team.ID)), nullInt(booking.Player.ID),
+			nullString(booking.Card))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func replaceSubstitutions(ctx context.Context, db execer, matchID int, substitutions []football.Substitutions) error {
+	if _, err := db.ExecContext(ctx, "DELETE FROM substitutions WHERE match_id = ?", matchID); err != nil {
+		return err
+	}
+
+	for i, substitution := range substitutions {
+		if err := upsertEventRefs(ctx, db, substitution.Team, substitution.PlayerOut, substitution.PlayerIn); err != nil {
+			return err
+		}
+
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO substitutions (match_id, seq, minute, team_id, player_out_id, player_in_id)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			matchID, i, substitution.Minute, nullInt(int64(substitution.Team.ID)),
+			nullInt(substitution.PlayerOut.ID), nullInt(substitution.PlayerIn.ID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upsertEventRefs stores the team and players a match event refers to.
+func upsertEventRefs(ctx context.Context, db execer, team football.Team, players ...football.Player) error {
+	if err := upsertTeamRow(ctx, db, team); err != nil {
+		return err
+	}
+
+	for _, player := range players {
+		if err := upsertPlayer(ctx, db, player); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nullString maps the empty string to NULL.
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// scoreValues returns the winner, duration and goals columns of a match.
+// Goals are NULL, keeping what is stored, when the match has no score or
+// has not kicked off, as the API then sends null for them; extra time and
+// penalties are NULL unless the match lasted that long.
+func scoreValues(match football.Match) []interface{} {
+	values := make([]interface{}, 10)
+	if match.Score == nil {
+		return values
+	}
+
+	score := match.Score
+	values[0], values[1] = nullString(score.Winner), nullString(score.Duration)
+
+	switch football.Status(match.Status) {
+	case football.StatusScheduled, football.StatusPostPoned, football.StatusCanceled, football.StatusTimed, "":
+		return values
+	}
+
+	periods := []football.Time{score.FullTime, score.HalfTime}
+	if score.Duration == "EXTRA_TIME" || score.Duration == "PENALTY_SHOOTOUT" {
+		periods = append(periods, score.ExtraTime)
+	}
+	if score.Duration == "PENALTY_SHOOTOUT" {
+		periods = append(periods, score.Penalties)
+	}
+
+	for i, period := range periods {
+		values[2+2*i], values[3+2*i] = period.HomeTeam, period.AwayTeam
+	}
+
+	return values
+}
+
+// nullInt maps zero to NULL.
+func nullInt(v int64) interface{} {
+	if v == 0 {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,220 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsertCompetition(t *testing.T) {
+	s := testStore(t)
+	ctx := context.Background()
+
+	competition := football.Competition{
+		ID:   2013,
+		Area: football.Area{ID: 2032, Name: "Brazil", CountryCode: "BRA"},
+		Name: "Campeonato Brasileiro Série A",
+		Code: "BSA",
+		Plan: "TIER_ONE",
+		CurrentSeason: football.Season{
+			ID:              734,
+			StartDate:       "2021-05-29",
+			EndDate:         "2021-12-05",
+			CurrentMatchday: 12,
+		},
+		Seasons: []football.Season{
+			{ID: 734, StartDate: "2021-05-29", EndDate: "2021-12-05", CurrentMatchday: 12},
+			{ID: 600, StartDate: "2020-08-08", EndDate: "2021-02-25", Winner: football.Winner{ID: 1783, Name: "CR Flamengo"}},
+		},
+		LastUpdated: "2021-08-01T00:00:00Z",
+	}
+
+	assert.Nil(t, s.UpsertCompetition(ctx, competition))
+
+	// A partial struct, as embedded in a match, keeps the stored fields.
+	assert.Nil(t, s.UpsertCompetition(ctx, football.Competition{ID: 2013, Name: "Série A"}))
+
+	stored, err := s.Competition(ctx, 2013)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Série A", stored.Name)
+	assert.Equal(t, "BSA", stored.Code)
+	assert.Equal(t, "TIER_ONE", stored.Plan)
+	assert.Equal(t, "Brazil", stored.Area.Name)
+	assert.Equal(t, 12, stored.CurrentSeason.CurrentMatchday)
+	assert.Equal(t, 2, len(stored.Seasons))
+	assert.Equal(t, "CR Flamengo", stored.Seasons[1].Winner.Name)
+
+	competitions, err := s.Competitions(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(competitions))
+}
+
+func TestUpsertTeam_ReplacesSquad(t *testing.T) {
+	s := testStore(t)
+	ctx := context.Background()
+
+	team := football.Team{
+		ID:        1765,
+		Area:      &football.Area{ID: 2032, Name: "Brazil"},
+		Name:      "Fluminense FC",
+		ShortName: "Fluminense",
+		Tla:       "FLU",
+		Founded:   1902,
+		Squad: &[]football.Player{
+			{ID: 1, Name: "Fred", Position: "Attacker", Role: "PLAYER"},
+			{ID: 2, Name: "Nenê", Position: "Midfielder", Role: "PLAYER"},
+		},
+	}
+	assert.Nil(t, s.UpsertTeam(ctx, team))
+
+	team.Squad = &[]football.Player{{ID: 1, Name: "Fred", Position: "Attacker", Role: "PLAYER"}}
+	assert.Nil(t, s.UpsertTeam(ctx, team))
+
+	stored, err := s.Team(ctx, 1765)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "FLU", stored.Tla)
+	assert.Equal(t, 1902, stored.Founded)
+	assert.Equal(t, "Brazil", stored.Area.Name)
+	assert.Equal(t, []football.Player{{ID: 1, Name: "Fred", Position: "Attacker", Role: "PLAYER"}}, *stored.Squad)
+
+	// Players who left the squad are kept.
+	player, err := s.Player(ctx, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "Nenê", player.Name)
+}
+
+func TestUpsertMatch(t *testing.T) {
+	s := testStore(t)
+	ctx := context.Background()
+
+	match := football.Match{
+		ID:          204950,
+		Competition: &football.Competition{ID: 2013, Name: "Série A"},
+		Season:      &football.Season{ID: 15, StartDate: "2018-04-14", EndDate: "2018-12-02"},
+		UtcDate:     "2018-08-13T23:00:00Z",
+		Status:      string(football.StatusFinished),
+		Matchday:    18,
+		Stage:       "REGULAR_SEASON",
+		Group:       "Regular Season",
+		HomeTeam:    &football.Team{ID: 1765, Name: "Fluminense FC"},
+		AwayTeam:    &football.Team{ID: 6684, Name: "SC Internacional"},
+		Score: &football.Score{
+			Winner:   "AWAY_TEAM",
+			Duration: "REGULAR",
+			FullTime: football.Time{HomeTeam: 0, AwayTeam: 1},
+		},
+		Goals: []football.Goals{
+			{
+				Minute:    90,
+				ExtraTime: float64(2),
+				Type:      "REGULAR",
+				Team:      football.Team{ID: 6684, Name: "SC Internacional"},
+				Scorer:    football.Player{ID: 1588, Name: "Nicolás López"},
+				Assist:    football.Player{ID: 1575, Name: "Rodrigo Dourado"},
+			},
+		},
+		Bookings: []football.Bookings{
+			{Minute: 30, Team: football.Team{ID: 1765}, Player: football.Player{ID: 1068, Name: "Gum"}, Card: "YELLOW_CARD"},
+		},
+		Substitutions: []football.Substitutions{
+			{
+				Minute:    60,
+				Team:      football.Team{ID: 1765},
+				PlayerOut: football.Player{ID: 1083, Name: "Jádson"},
+				PlayerIn:  football.Player{ID: 1084, Name: "Pedro"},
+			},
+		},
+	}
+	assert.Nil(t, s.UpsertMatch(ctx, match))
+
+	// A list entry without events keeps the stored ones.
+	listed := match
+	listed.Goals, listed.Bookings, listed.Substitutions = nil, nil, nil
+	assert.Nil(t, s.UpsertMatch(ctx, listed))
+
+	stored, err := s.Match(ctx, 204950)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Série A", stored.Competition.Name)
+	assert.Equal(t, 15, stored.Season.ID)
+	assert.Equal(t, "Fluminense FC", stored.HomeTeam.Name)
+	assert.Equal(t, "SC Internacional", stored.AwayTeam.Name)
+	assert.Equal(t, match.Score, stored.Score)
+	assert.Equal(t, match.Goals, stored.Goals)
+	assert.Equal(t, []football.Bookings{
+		{Minute: 30, Team: football.Team{ID: 1765, Name: "Fluminense FC"}, Player: football.Player{ID: 1068, Name: "Gum"}, Card: "YELLOW_CARD"},
+	}, stored.Bookings)
+	assert.Equal(t, "Pedro", stored.Substitutions[0].PlayerIn.Name)
+
+	// An empty slice clears them.
+	listed.Goals = []football.Goals{}
+	assert.Nil(t, s.UpsertMatch(ctx, listed))
+
+	stored, err = s.Match(ctx, 204950)
+	assert.Nil(t, err)
+	assert.Equal(t, []football.Goals{}, stored.Goals)
+	assert.Equal(t, 1, len(stored.Bookings))
+}
+
+func TestUpsertMatch_KeepsScore(t *testing.T) {
+	s := testStore(t)
+	ctx := context.Background()
+
+	finished := football.Match{
+		ID:     327125,
+		Status: "FINISHED",
+		Score: &football.Score{
+			Winner:   "AWAY_TEAM",
+			Duration: "REGULAR",
+			FullTime: football.Time{HomeTeam: 0, AwayTeam: 2},
+			HalfTime: football.Time{HomeTeam: 0, AwayTeam: 1},
+		},
+	}
+	assert.Nil(t, s.UpsertMatch(ctx, finished))
+
+	// A score-less copy, as embedded elsewhere, keeps the result.
+	assert.Nil(t, s.UpsertMatch(ctx, football.Match{ID: 327125, Venue: "Maracanã"}))
+
+	stored, err := s.Match(ctx, 327125)
+	assert.Nil(t, err)
+	assert.Equal(t, "Maracanã", stored.Venue)
+	assert.Equal(t, "AWAY_TEAM", stored.Score.Winner)
+	assert.Equal(t, football.Time{HomeTeam: 0, AwayTeam: 2}, stored.Score.FullTime)
+	assert.Equal(t, football.Time{HomeTeam: 0, AwayTeam: 1}, stored.Score.HalfTime)
+
+	var fullTime, extraTime sql.NullInt64
+	row := s.DB().QueryRowContext(ctx, "SELECT full_time_home, extra_time_home FROM matches WHERE id = ?", 327125)
+	assert.Nil(t, row.Scan(&fullTime, &extraTime))
+	assert.True(t, fullTime.Valid)
+	assert.False(t, extraTime.Valid)
+
+	// Unplayed matches have no goals yet.
+	assert.Nil(t, s.UpsertMatch(ctx, football.Match{ID: 1, Status: "SCHEDULED", Score: &football.Score{}}))
+
+	row = s.DB().QueryRowContext(ctx, "SELECT full_time_home FROM matches WHERE id = ?", 1)
+	assert.Nil(t, row.Scan(&fullTime))
+	assert.False(t, fullTime.Valid)
+}
+
+func TestUpsertMatch_UndecidedTeams(t *testing.T) {
+	s := testStore(t)
+	ctx := context.Background()
+
+	match := football.Match{ID: 1, HomeTeam: &football.Team{}, AwayTeam: &football.Team{ID: 61, Name: "Chelsea FC"}}
+	assert.Nil(t, s.UpsertMatch(ctx, match))
+
+	var teams int
+	assert.Nil(t, s.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM teams").Scan(&teams))
+	assert.Equal(t, 1, teams)
+
+	var homeID, awayID sql.NullInt64
+	row := s.DB().QueryRowContext(ctx, "SELECT home_team_id, away_team_id FROM matches WHERE id = ?", 1)
+	assert.Nil(t, row.Scan(&homeID, &awayID))
+	assert.False(t, homeID.Valid)
+	assert.Equal(t, int64(61), awayID.Int64)
+}
@@ -0,0 +1,66 @@
+package syncer
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kind is the kind of entity a Change refers to.
+type Kind string
+
+const (
+	KindCompetition Kind = "competition"
+	KindTeam        Kind = "team"
+	KindMatch       Kind = "match"
+)
+
+// Action tells what happened to an entity.
+type Action string
+
+const (
+	Created Action = "created"
+	Updated Action = "updated"
+)
+
+// Change is an entity that was created or updated in the store.
+type Change struct {
+	Kind   Kind   `json:"kind"`
+	ID     int    `json:"id"`
+	Action Action `json:"action"`
+	// Status is the match status after the change, for matches.
+	Status string `json:"status,omitempty"`
+	// Previous is the match status before the change, for updated matches.
+	Previous string `json:"previous,omitempty"`
+}
+
+func (c Change) String() string {
+	if c.Kind == KindMatch && c.Previous != "" && c.Previous != c.Status {
+		return fmt.Sprintf("%s %d %s (%s -> %s)", c.Kind, c.ID, c.Action, c.Previous, c.Status)
+	}
+	return fmt.Sprintf("%s %d %s", c.Kind, c.ID, c.Action)
+}
+
+// Report summarizes a run.
+type Report struct {
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	// Requests is the number of API requests made.
+	Requests int `json:"requests"`
+	// Steps lists the steps completed, such as "teams:2013".
+	Steps   []string `json:"steps"`
+	Changes []Change `json:"changes"`
+	// Exhausted is set when the run stopped because the request budget was
+	// spent. The remaining steps run next time.
+	Exhausted bool `json:"exhausted"`
+}
+
+// Count returns the number of changes of kind.
+func (r *Report) Count(kind Kind) int {
+	count := 0
+	for _, change := range r.Changes {
+		if change.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
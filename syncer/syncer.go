@@ -0,0 +1,425 @@
+// Package syncer keeps a store current with the API while staying within the
+// request quota.
+//
+// A run discovers competitions, refreshes their teams and season fixtures
+// when they are older than the configured refresh periods, and in between
+// re-polls only the matches that are live or kicked off recently, writing
+// those whose LastUpdated moved. Each completed step is recorded as a
+// checkpoint in the store, so a run that crashes or spends its budget
+// resumes where it stopped.
+package syncer
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/matheustex/football-data-sdk/store"
+)
+
+// errExhausted stops a run when the request budget is spent.
+var errExhausted = errors.New("Request budget exhausted")
+
+// Options configures a Syncer. Zero fields take the defaults.
+type Options struct {
+	// Competitions restricts the sync to competitions by code or ID. All
+	// discovered competitions are synced when empty.
+	Competitions []string
+	// Plan restricts discovery to competitions available in the plan,
+	// such as "TIER_ONE".
+	Plan string
+	// Squads also fetches every team to store its squad, at one request
+	// per team.
+	Squads bool
+	// Details fetches changed matches one by one to store their goals,
+	// bookings and substitutions.
+	Details bool
+	// Budget is the maximum number of requests per run. Zero means no
+	// limit.
+	Budget int
+	// Interval is the minimum delay between requests. Defaults to 6
+	// seconds, the free tier quota of 10 requests per minute.
+	Interval time.Duration
+	// DiscoveryRefresh is how often competitions are listed again.
+	// Defaults to a week.
+	DiscoveryRefresh time.Duration
+	// TeamsRefresh is how often the teams of a competition are fetched
+	// again. Defaults to a day.
+	TeamsRefresh time.Duration
+	// FixturesRefresh is how often all season fixtures of a competition
+	// are fetched again. Defaults to a day.
+	FixturesRefresh time.Duration
+	// RecentWindow is how long after kick-off a match keeps being
+	// re-polled. Defaults to 3 hours.
+	RecentWindow time.Duration
+}
+
+// Syncer copies data from the API to a store.
+type Syncer struct {
+	Client  *football.Client
+	Store   *store.Store
+	Options Options
+
+	// now returns the current time; replaced in tests.
+	now func() time.Time
+
+	report      *Report
+	lastRequest time.Time
+}
+
+// New returns a Syncer writing to s. A nil opts takes the defaults.
+func New(client *football.Client, s *store.Store, opts *Options) *Syncer {
+	options := Options{}
+	if opts != nil {
+		options = *opts
+	}
+
+	if options.Interval == 0 {
+		options.Interval = 6 * time.Second
+	}
+	if options.DiscoveryRefresh == 0 {
+		options.DiscoveryRefresh = 7 * 24 * time.Hour
+	}
+	if options.TeamsRefresh == 0 {
+		options.TeamsRefresh = 24 * time.Hour
+	}
+	if options.FixturesRefresh == 0 {
+		options.FixturesRefresh = 24 * time.Hour
+	}
+	if options.RecentWindow == 0 {
+		options.RecentWindow = 3 * time.Hour
+	}
+
+	return &Syncer{Client: client, Store: s, Options: options, now: time.Now}
+}
+
+// Run performs a sync and reports what changed. Running out of budget is
+// not an error; it is flagged in the report.
+func (s *Syncer) Run(ctx context.Context) (*Report, error) {
+	s.report = &Report{Started: s.now(), Changes: []Change{}, Steps: []string{}}
+	report := s.report
+
+	err := s.run(ctx)
+	report.Finished = s.now()
+
+	if err == errExhausted {
+		report.Exhausted = true
+		return report, nil
+	}
+
+	return report, err
+}
+
+func (s *Syncer) run(ctx context.Context) error {
+	if err := s.step(ctx, "competitions", s.Options.DiscoveryRefresh, s.discover); err != nil {
+		return err
+	}
+
+	competitions, err := s.competitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, competition := range competitions {
+		id := competition.ID
+
+		err := s.step(ctx, "teams:"+strconv.Itoa(id), s.Options.TeamsRefresh, func(ctx context.Context) error {
+			return s.syncTeams(ctx, id)
+		})
+		if err != nil {
+			return err
+		}
+
+		fixtures := "fixtures:" + strconv.Itoa(id)
+		stale, err := s.due(ctx, fixtures, s.Options.FixturesRefresh)
+		if err != nil {
+			return err
+		}
+
+		if stale {
+			err = s.step(ctx, fixtures, s.Options.FixturesRefresh, func(ctx context.Context) error {
+				return s.syncMatches(ctx, id, nil)
+			})
+		} else {
+			err = s.pollRecent(ctx, id)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// due tells whether the checkpoint key is missing or older than refresh.
+func (s *Syncer) due(ctx context.Context, key string, refresh time.Duration) (bool, error) {
+	at, ok, err := s.Store.Checkpoint(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	return !ok || s.now().Sub(at) >= refresh, nil
+}
+
+// step runs fn when the checkpoint key is due and records it once fn
+// succeeds.
+func (s *Syncer) step(ctx context.Context, key string, refresh time.Duration, fn func(ctx context.Context) error) error {
+	due, err := s.due(ctx, key, refresh)
+	if err != nil || !due {
+		return err
+	}
+
+	if err := fn(ctx); err != nil {
+		return err
+	}
+
+	s.report.Steps = append(s.report.Steps, key)
+	return s.Store.SetCheckpoint(ctx, key, s.now())
+}
+
+// request waits for the throttle interval and counts the request against
+// the budget.
+func (s *Syncer) request(ctx context.Context) error {
+	if s.Options.Budget > 0 && s.report.Requests >= s.Options.Budget {
+		return errExhausted
+	}
+
+	if !s.lastRequest.IsZero() {
+		wait := s.Options.Interval - time.Since(s.lastRequest)
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	s.lastRequest = time.Now()
+	s.report.Requests++
+	return nil
+}
+
+func (s *Syncer) discover(ctx context.Context) error {
+	if err := s.request(ctx); err != nil {
+		return err
+	}
+
+	var filters *football.CompetitionFiltersOptions
+	if s.Options.Plan != "" {
+		filters = &football.CompetitionFiltersOptions{Plan: s.Options.Plan}
+	}
+
+	list, err := s.Client.Competitions.List(ctx, filters)
+	if err != nil {
+		return err
+	}
+
+	for _, competition := range list.Competitions {
+		stored, err := s.Store.Competition(ctx, competition.ID)
+		if err != nil && err != store.ErrNotFound {
+			return err
+		}
+
+		if err := s.Store.UpsertCompetition(ctx, competition); err != nil {
+			return err
+		}
+
+		if stored == nil {
+			s.record(Change{Kind: KindCompetition, ID: competition.ID, Action: Created})
+		} else if stored.LastUpdated != competition.LastUpdated {
+			s.record(Change{Kind: KindCompetition, ID: competition.ID, Action: Updated})
+		}
+	}
+
+	return nil
+}
+
+// competitions returns the stored competitions selected by the options.
+func (s *Syncer) competitions(ctx context.Context) ([]football.Competition, error) {
+	all, err := s.Store.Competitions(ctx)
+	if err != nil || len(s.Options.Competitions) == 0 {
+		return all, err
+	}
+
+	selected := []football.Competition{}
+	for _, competition := range all {
+		for _, want := range s.Options.Competitions {
+			if want == competition.Code || want == strconv.Itoa(competition.ID) {
+				selected = append(selected, competition)
+				break
+			}
+		}
+	}
+
+	return selected, nil
+}
+
+func (s *Syncer) syncTeams(ctx context.Context, competitionID int) error {
+	if err := s.request(ctx); err != nil {
+		return err
+	}
+
+	teams, err := s.Client.Competitions.Teams(ctx, strconv.Itoa(competitionID), nil)
+	if err != nil {
+		return err
+	}
+
+	for _, team := range teams.Teams {
+		if s.Options.Squads {
+			if err := s.request(ctx); err != nil {
+				return err
+			}
+
+			full, err := s.Client.Teams.Find(ctx, strconv.Itoa(team.ID))
+			if err != nil {
+				return err
+			}
+			team = *full
+		}
+
+		if err := s.upsertTeam(ctx, team); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Syncer) upsertTeam(ctx context.Context, team football.Team) error {
+	stored, err := s.Store.Team(ctx, team.ID)
+	if err != nil && err != store.ErrNotFound {
+		return err
+	}
+
+	if err := s.Store.UpsertTeam(ctx, team); err != nil {
+		return err
+	}
+
+	// Teams embedded in matches are stored without LastUpdated.
+	if stored == nil {
+		s.record(Change{Kind: KindTeam, ID: team.ID, Action: Created})
+	} else if stored.LastUpdated != team.LastUpdated {
+		s.record(Change{Kind: KindTeam, ID: team.ID, Action: Updated})
+	}
+
+	return nil
+}
+
+// pollRecent re-polls the matches of a competition that are live or kicked
+// off within the recent window, if there are any.
+func (s *Syncer) pollRecent(ctx context.Context, competitionID int) error {
+	stored, err := s.Store.Matches(ctx, &store.MatchQuery{CompetitionID: competitionID})
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	from, to := "", ""
+
+	for _, match := range stored {
+		if !s.recent(match, now) {
+			continue
+		}
+
+		date := match.UtcDate
+		if len(date) >= 10 {
+			date = date[:10]
+		}
+		if from == "" || date < from {
+			from = date
+		}
+		if date > to {
+			to = date
+		}
+	}
+
+	if from == "" {
+		return nil
+	}
+
+	return s.syncMatches(ctx, competitionID, &football.CompetitionMatchesFiltersOptions{DateFrom: from, DateTo: to})
+}
+
+// recent tells whether a match is live or kicked off within the recent
+// window.
+func (s *Syncer) recent(match football.Match, now time.Time) bool {
+	switch football.Status(match.Status) {
+	case football.StatusLive, football.StatusInPlay, football.StatusPaused:
+		return true
+	}
+
+	kickoff, err := time.Parse(time.RFC3339, match.UtcDate)
+	if err != nil {
+		return false
+	}
+
+	return !kickoff.After(now) && now.Sub(kickoff) <= s.Options.RecentWindow
+}
+
+// syncMatches fetches the matches of a competition and writes those whose
+// LastUpdated moved.
+func (s *Syncer) syncMatches(ctx context.Context, competitionID int, filters *football.CompetitionMatchesFiltersOptions) error {
+	if err := s.request(ctx); err != nil {
+		return err
+	}
+
+	list, err := s.Client.Competitions.Matches(ctx, strconv.Itoa(competitionID), filters)
+	if err != nil {
+		return err
+	}
+
+	stored, err := s.Store.Matches(ctx, &store.MatchQuery{CompetitionID: competitionID})
+	if err != nil {
+		return err
+	}
+
+	previous := map[int]football.Match{}
+	for _, match := range stored {
+		previous[match.ID] = match
+	}
+
+	for _, match := range list.Matches {
+		old, exists := previous[match.ID]
+		if exists && old.LastUpdated == match.LastUpdated && old.Status == match.Status {
+			continue
+		}
+
+		if s.Options.Details {
+			if err := s.request(ctx); err != nil {
+				return err
+			}
+
+			detail, err := s.Client.Matches.Find(ctx, strconv.Itoa(match.ID))
+			if err != nil {
+				return err
+			}
+			match = detail.Match
+		}
+
+		if match.Competition == nil {
+			match.Competition = &football.Competition{ID: competitionID}
+		}
+
+		if err := s.Store.UpsertMatch(ctx, match); err != nil {
+			return err
+		}
+
+		change := Change{Kind: KindMatch, ID: match.ID, Action: Created, Status: match.Status}
+		if exists {
+			change.Action = Updated
+			change.Previous = old.Status
+		}
+		s.record(change)
+	}
+
+	return nil
+}
+
+func (s *Syncer) record(change Change) {
+	s.report.Changes = append(s.report.Changes, change)
+}
@@ -0,0 +1,153 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/matheustex/football-data-sdk/store"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2021, 8, 1, 20, 0, 0, 0, time.UTC)
+
+func testSyncer(t *testing.T, opts *Options) (*Syncer, *store.Store) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v2/competitions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 2, "competitions": [
+			{"id": 2013, "name": "Série A", "code": "BSA", "lastUpdated": "2021-07-01T00:00:00Z"},
+			{"id": 2021, "name": "Premier League", "code": "PL", "lastUpdated": "2021-07-01T00:00:00Z"}
+		]}`)
+	})
+
+	mux.HandleFunc("/v2/competitions/2013/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 2, "teams": [
+			{"id": 1, "name": "Fluminense FC", "lastUpdated": "2021-07-01T00:00:00Z"},
+			{"id": 2, "name": "SC Internacional", "lastUpdated": "2021-07-01T00:00:00Z"}
+		]}`)
+	})
+
+	mux.HandleFunc("/v2/competitions/2013/matches", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("dateFrom") == "2021-08-01" && r.URL.Query().Get("dateTo") == "2021-08-01" {
+			fmt.Fprint(w, `{"count": 1, "matches": [
+				{"id": 11, "utcDate": "2021-08-01T19:00:00Z", "status": "FINISHED", "lastUpdated": "2021-08-01T19:50:00Z",
+					"homeTeam": {"id": 2}, "awayTeam": {"id": 1}, "score": {"fullTime": {"homeTeam": 2, "awayTeam": 0}}}
+			]}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"count": 3, "matches": [
+			{"id": 10, "utcDate": "2021-07-01T19:00:00Z", "status": "FINISHED", "lastUpdated": "2021-07-01T21:00:00Z",
+				"homeTeam": {"id": 1}, "awayTeam": {"id": 2}},
+			{"id": 11, "utcDate": "2021-08-01T19:00:00Z", "status": "IN_PLAY", "lastUpdated": "2021-08-01T19:30:00Z",
+				"homeTeam": {"id": 2}, "awayTeam": {"id": 1}},
+			{"id": 12, "utcDate": "2021-08-10T19:00:00Z", "status": "SCHEDULED", "lastUpdated": "2021-07-01T00:00:00Z",
+				"homeTeam": {"id": 1}, "awayTeam": {"id": 2}}
+		]}`)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	s, err := store.Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	client := football.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/v2")
+	syncer := New(client, s, opts)
+	syncer.now = func() time.Time { return now }
+
+	return syncer, s
+}
+
+func TestSyncer_Run(t *testing.T) {
+	syncer, s := testSyncer(t, &Options{Competitions: []string{"BSA"}, Interval: time.Nanosecond})
+	ctx := context.Background()
+
+	report, err := syncer.Run(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, report.Requests)
+	assert.Equal(t, []string{"competitions", "teams:2013", "fixtures:2013"}, report.Steps)
+	assert.Equal(t, 2, report.Count(KindCompetition))
+	assert.Equal(t, 2, report.Count(KindTeam))
+	assert.Equal(t, 3, report.Count(KindMatch))
+	assert.False(t, report.Exhausted)
+
+	// Only the live match is re-polled.
+	report, err = syncer.Run(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, report.Requests)
+	assert.Empty(t, report.Steps)
+	assert.Equal(t, []Change{
+		{Kind: KindMatch, ID: 11, Action: Updated, Status: "FINISHED", Previous: "IN_PLAY"},
+	}, report.Changes)
+	assert.Equal(t, "match 11 updated (IN_PLAY -> FINISHED)", report.Changes[0].String())
+
+	match, err := s.Match(ctx, 11)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, match.Score.FullTime.HomeTeam)
+	assert.Equal(t, 2013, match.Competition.ID)
+
+	// The finished match is still within the recent window but unchanged.
+	report, err = syncer.Run(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, report.Requests)
+	assert.Empty(t, report.Changes)
+
+	// Past the window nothing is polled.
+	syncer.now = func() time.Time { return now.Add(4 * time.Hour) }
+	report, err = syncer.Run(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, report.Requests)
+}
+
+func TestSyncer_RunResumesWhenBudgetIsExhausted(t *testing.T) {
+	syncer, _ := testSyncer(t, &Options{Competitions: []string{"2013"}, Budget: 2, Interval: time.Nanosecond})
+	ctx := context.Background()
+
+	report, err := syncer.Run(ctx)
+
+	assert.Nil(t, err)
+	assert.True(t, report.Exhausted)
+	assert.Equal(t, 2, report.Requests)
+	assert.Equal(t, []string{"competitions", "teams:2013"}, report.Steps)
+
+	report, err = syncer.Run(ctx)
+
+	assert.Nil(t, err)
+	assert.False(t, report.Exhausted)
+	assert.Equal(t, 1, report.Requests)
+	assert.Equal(t, []string{"fixtures:2013"}, report.Steps)
+	assert.Equal(t, 3, report.Count(KindMatch))
+}
+
+func TestSyncer_RunStopsOnAPIError(t *testing.T) {
+	syncer, s := testSyncer(t, &Options{Competitions: []string{"PL"}, Interval: time.Nanosecond})
+	ctx := context.Background()
+
+	_, err := syncer.Run(ctx)
+
+	assert.NotNil(t, err)
+
+	// Discovery succeeded and is not repeated.
+	_, ok, err := s.Checkpoint(ctx, "competitions")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	_, ok, err = s.Checkpoint(ctx, "teams:2021")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
@@ -43,11 +43,11 @@ type TeamMatches struct {
 }
 
 type TeamMatchesFiltersOptions struct {
-	DateFrom string `json:"dateFrom,omitempty"`
-	DateTo   string `json:"dateTo,omitempty"`
-	Status   string `json:"status,omitempty"`
-	Venue    string `json:"venue,omitempty"`
-	Limit    int64  `json:"limit,omitempty"`
+	DateFrom string `url:"dateFrom,omitempty"`
+	DateTo   string `url:"dateTo,omitempty"`
+	Status   string `url:"status,omitempty"`
+	Venue    string `url:"venue,omitempty"`
+	Limit    int64  `url:"limit,omitempty"`
 }
 
 // Find takes a Team ID and returns the corresponding Team
@@ -60,7 +60,7 @@ func (s *TeamService) Find(ctx context.Context, id string) (*Team, error) {
 
 	team := &Team{}
 
-	_, err := s.client.Get(fmt.Sprintf("teams/%s", id), nil, &team)
+	_, err := s.client.GetContext(ctx, fmt.Sprintf("teams/%s", id), nil, &team)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +78,7 @@ func (s *TeamService) Matches(ctx context.Context, id string, filters *TeamMatch
 
 	teamMatches := &TeamMatches{}
 
-	_, err := s.client.Get(fmt.Sprintf("teams/%s/matches", id), filters, &teamMatches)
+	_, err := s.client.GetContext(ctx, fmt.Sprintf("teams/%s/matches", id), filters, &teamMatches)
 	if err != nil {
 		return nil, err
 	}
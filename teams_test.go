@@ -211,3 +211,27 @@ func TestTeamService_Matches(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expected, list)
 }
+
+func TestTeamService_MatchesFilters(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/v2/teams/1783/matches", func(w http.ResponseWriter, r *http.Request) {
+		assertMethod(t, "GET", r)
+		assert.Equal(t, "dateFrom=2021-08-01&dateTo=2021-08-31&limit=5&status=FINISHED&venue=HOME", r.URL.RawQuery)
+		fmt.Fprint(w, `{"count": 0, "matches": []}`)
+	})
+
+	filters := &TeamMatchesFiltersOptions{
+		DateFrom: "2021-08-01",
+		DateTo:   "2021-08-31",
+		Status:   "FINISHED",
+		Venue:    "HOME",
+		Limit:    5,
+	}
+
+	client := NewClient(httpClient)
+	_, err := client.Teams.Matches(context.Background(), "1783", filters)
+
+	assert.Nil(t, err)
+}
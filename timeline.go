@@ -0,0 +1,179 @@
+package football
+
+import (
+	"sort"
+)
+
+// TimelineEventType identifies the kind of an event of a match timeline.
+type TimelineEventType string
+
+const (
+	TimelineGoal         TimelineEventType = "GOAL"
+	TimelineBooking      TimelineEventType = "BOOKING"
+	TimelineSubstitution TimelineEventType = "SUBSTITUTION"
+)
+
+// Period is the part of a match an event happened in.
+type Period string
+
+const (
+	PeriodFirstHalf           Period = "FIRST_HALF"
+	PeriodSecondHalf          Period = "SECOND_HALF"
+	PeriodExtraTimeFirstHalf  Period = "EXTRA_TIME_FIRST_HALF"
+	PeriodExtraTimeSecondHalf Period = "EXTRA_TIME_SECOND_HALF"
+)
+
+// Cards that send a player off.
+const (
+	CardRed       = "RED_CARD"
+	CardYellowRed = "YELLOW_RED_CARD"
+)
+
+// TimelineEvent represents a goal, a booking or a substitution of a match.
+// Score is the score of the match after the event.
+type TimelineEvent struct {
+	Type         TimelineEventType `json:"type"`
+	Minute       int               `json:"minute"`
+	ExtraTime    int               `json:"extraTime,omitempty"` // Added time, e.g. 2 for 45+2.
+	Period       Period            `json:"period"`
+	Team         Team              `json:"team"`
+	Goal         *Goals            `json:"goal,omitempty"`
+	Booking      *Bookings         `json:"booking,omitempty"`
+	Substitution *Substitutions    `json:"substitution,omitempty"`
+	Score        Time              `json:"score"`
+}
+
+// Timeline merges the goals, bookings and substitutions of the match into
+// a single list ordered by minute. Goals are credited to the team in their
+// Team field, which is the benefiting team for own goals.
+func (m *Match) Timeline() []TimelineEvent {
+	events := []TimelineEvent{}
+
+	for i := range m.Goals {
+		goal := m.Goals[i]
+		events = append(events, TimelineEvent{
+			Type:      TimelineGoal,
+			Minute:    goal.Minute,
+			ExtraTime: goal.ExtraMinutes(),
+			Team:      goal.Team,
+			Goal:      &goal,
+		})
+	}
+	for i := range m.Bookings {
+		booking := m.Bookings[i]
+		events = append(events, TimelineEvent{
+			Type:    TimelineBooking,
+			Minute:  booking.Minute,
+			Team:    booking.Team,
+			Booking: &booking,
+		})
+	}
+	for i := range m.Substitutions {
+		substitution := m.Substitutions[i]
+		events = append(events, TimelineEvent{
+			Type:         TimelineSubstitution,
+			Minute:       substitution.Minute,
+			Team:         substitution.Team,
+			Substitution: &substitution,
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Minute != events[j].Minute {
+			return events[i].Minute < events[j].Minute
+		}
+		return events[i].ExtraTime < events[j].ExtraTime
+	})
+
+	score := Time{}
+	for i := range events {
+		event := &events[i]
+		event.Period = m.period(event.Minute, event.ExtraTime)
+
+		if event.Type == TimelineGoal {
+			if m.HomeTeam != nil && event.Team.ID == m.HomeTeam.ID {
+				score.HomeTeam++
+			} else if m.AwayTeam != nil && event.Team.ID == m.AwayTeam.ID {
+				score.AwayTeam++
+			}
+		}
+		event.Score = score
+	}
+
+	return events
+}
+
+// OnPitch returns the players of each team on the pitch at the given
+// minute, starting from the lineups and applying the substitutions and
+// sendings off that happened up to that minute.
+func (m *Match) OnPitch(minute int) (home []Player, away []Player) {
+	return m.onPitch(m.HomeTeam, minute), m.onPitch(m.AwayTeam, minute)
+}
+
+func (m *Match) onPitch(team *Team, minute int) []Player {
+	players := []Player{}
+	if team == nil || team.Lineup == nil {
+		return players
+	}
+	players = append(players, *team.Lineup...)
+
+	for _, event := range m.Timeline() {
+		if event.Minute > minute || event.Team.ID != team.ID {
+			continue
+		}
+
+		switch {
+		case event.Substitution != nil:
+			players = removePlayer(players, event.Substitution.PlayerOut.ID)
+			players = append(players, event.Substitution.PlayerIn)
+		case event.Booking != nil && sendsOff(event.Booking.Card):
+			players = removePlayer(players, event.Booking.Player.ID)
+		}
+	}
+
+	return players
+}
+
+// period returns the part of the match of an event. Minutes past 90 are
+// added time of the second half unless the match went to extra time.
+func (m *Match) period(minute, extraTime int) Period {
+	extraTimePlayed := m.Score != nil && len(m.Score.Duration) > 0 && m.Score.Duration != "REGULAR"
+
+	switch {
+	case minute <= 45:
+		return PeriodFirstHalf
+	case minute <= 90 || !extraTimePlayed:
+		return PeriodSecondHalf
+	case minute <= 105:
+		return PeriodExtraTimeFirstHalf
+	default:
+		return PeriodExtraTimeSecondHalf
+	}
+}
+
+func sendsOff(card string) bool {
+	return card == CardRed || card == CardYellowRed
+}
+
+func removePlayer(players []Player, id int64) []Player {
+	result := players[:0]
+	for _, player := range players {
+		if player.ID != id {
+			result = append(result, player)
+		}
+	}
+	return result
+}
+
+// ExtraMinutes returns the added time of the goal, e.g. 2 for 45+2, which
+// the API sends as a JSON number or not at all.
+func (g Goals) ExtraMinutes() int {
+	switch n := g.ExtraTime.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	default:
+		return 0
+	}
+}
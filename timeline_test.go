@@ -0,0 +1,115 @@
+package football
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func timelineMatch() *Match {
+	home := &Team{
+		ID:   1765,
+		Name: "Fluminense FC",
+		Lineup: &[]Player{
+			{ID: 1, Name: "Muriel"},
+			{ID: 2, Name: "Gum"},
+			{ID: 3, Name: "Jádson"},
+		},
+	}
+	away := &Team{
+		ID:   6684,
+		Name: "SC Internacional",
+		Lineup: &[]Player{
+			{ID: 11, Name: "Marcelo Lomba"},
+			{ID: 12, Name: "Nicolás López"},
+		},
+	}
+
+	match := &Match{}
+	json.Unmarshal([]byte(`{
+		"score": {"duration": "EXTRA_TIME"},
+		"goals": [
+			{"minute": 90, "extraTime": 3, "team": {"id": 1765}, "scorer": {"id": 3}},
+			{"minute": 23, "extraTime": null, "team": {"id": 6684}, "scorer": {"id": 12}},
+			{"minute": 110, "team": {"id": 1765}, "scorer": {"id": 4}}
+		],
+		"bookings": [
+			{"minute": 90, "team": {"id": 6684}, "player": {"id": 11}, "card": "YELLOW_CARD"},
+			{"minute": 60, "team": {"id": 1765}, "player": {"id": 2}, "card": "RED_CARD"}
+		],
+		"substitutions": [
+			{"minute": 70, "team": {"id": 1765}, "playerOut": {"id": 3}, "playerIn": {"id": 4, "name": "Pedro"}}
+		]
+	}`), match)
+	match.HomeTeam = home
+	match.AwayTeam = away
+
+	return match
+}
+
+func TestMatch_Timeline(t *testing.T) {
+	timeline := timelineMatch().Timeline()
+
+	assert.Len(t, timeline, 6)
+
+	types := []TimelineEventType{}
+	for _, event := range timeline {
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []TimelineEventType{
+		TimelineGoal,
+		TimelineBooking,
+		TimelineSubstitution,
+		TimelineBooking,
+		TimelineGoal,
+		TimelineGoal,
+	}, types)
+
+	assert.Equal(t, PeriodFirstHalf, timeline[0].Period)
+	assert.Equal(t, Time{AwayTeam: 1}, timeline[0].Score)
+
+	assert.Equal(t, 90, timeline[4].Minute)
+	assert.Equal(t, 3, timeline[4].ExtraTime)
+	assert.Equal(t, PeriodSecondHalf, timeline[4].Period)
+	assert.Equal(t, Time{HomeTeam: 1, AwayTeam: 1}, timeline[4].Score)
+
+	assert.Equal(t, PeriodExtraTimeSecondHalf, timeline[5].Period)
+	assert.Equal(t, Time{HomeTeam: 2, AwayTeam: 1}, timeline[5].Score)
+	assert.Equal(t, int64(4), timeline[5].Goal.Scorer.ID)
+}
+
+func TestMatch_TimelineRegularDuration(t *testing.T) {
+	match := &Match{
+		Score:    &Score{Duration: "REGULAR"},
+		Bookings: []Bookings{{Minute: 94, Team: Team{ID: 1}}},
+	}
+
+	assert.Equal(t, PeriodSecondHalf, match.Timeline()[0].Period)
+}
+
+func TestMatch_OnPitch(t *testing.T) {
+	match := timelineMatch()
+
+	ids := func(players []Player) []int64 {
+		result := []int64{}
+		for _, player := range players {
+			result = append(result, player.ID)
+		}
+		return result
+	}
+
+	home, away := match.OnPitch(0)
+	assert.Equal(t, []int64{1, 2, 3}, ids(home))
+	assert.Equal(t, []int64{11, 12}, ids(away))
+
+	home, _ = match.OnPitch(60)
+	assert.Equal(t, []int64{1, 3}, ids(home))
+
+	home, away = match.OnPitch(120)
+	assert.Equal(t, []int64{1, 4}, ids(home))
+	assert.Equal(t, []int64{11, 12}, ids(away))
+
+	// The lineup of the match is left untouched.
+	assert.Len(t, *match.HomeTeam.Lineup, 3)
+}
@@ -10,10 +10,10 @@ type Season struct {
 }
 
 type Standing struct {
-	Stage string      `json:"stage,omitempty"`
-	Type  string      `json:"type,omitempty"`
-	Group interface{} `json:"group,omitempty"`
-	Table []Table     `json:"table,omitempty"`
+	Stage string  `json:"stage,omitempty"`
+	Type  string  `json:"type,omitempty"`
+	Group string  `json:"group,omitempty"`
+	Table []Table `json:"table,omitempty"`
 }
 
 type StandingType string
@@ -28,6 +28,7 @@ type Status string
 
 const (
 	StatusScheduled Status = "SCHEDULED"
+	StatusTimed     Status = "TIMED" // Scheduled, with a confirmed kick-off time.
 	StatusLive      Status = "LIVE"
 	StatusInPlay    Status = "IN_PLAY"
 	StatusPaused    Status = "PAUSED"
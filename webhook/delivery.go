@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 signature of the request body.
+	SignatureHeader = "X-Football-Signature"
+	// EventHeader carries the type of the delivered event.
+	EventHeader = "X-Football-Event"
+	// DeliveryHeader carries the ID of the delivered event.
+	DeliveryHeader = "X-Football-Delivery"
+
+	defaultMaxAttempts = 5
+	defaultBackoff     = time.Second
+)
+
+// Sign returns the signature of body for the given secret, in the format
+// sent on the SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is a valid signature of body for the
+// given secret. Consumers should use it to authenticate deliveries.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// DeadLetter represents an event that could not be delivered to a
+// subscription after all attempts.
+type DeadLetter struct {
+	Subscription string    `json:"subscription"`
+	URL          string    `json:"url"`
+	Event        Event     `json:"event"`
+	Attempts     int       `json:"attempts"`
+	Error        string    `json:"error"`
+	FailedAt     time.Time `json:"failedAt"`
+}
+
+// DeadLetterLog stores failed deliveries.
+type DeadLetterLog interface {
+	Record(letter DeadLetter) error
+}
+
+// JSONDeadLetterLog writes each failed delivery as a line of JSON.
+type JSONDeadLetterLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONDeadLetterLog returns a DeadLetterLog writing to w.
+func NewJSONDeadLetterLog(w io.Writer) *JSONDeadLetterLog {
+	return &JSONDeadLetterLog{w: w}
+}
+
+// Record writes letter to the underlying writer.
+func (l *JSONDeadLetterLog) Record(letter DeadLetter) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return json.NewEncoder(l.w).Encode(letter)
+}
+
+// Deliverer posts events to subscriptions, retrying failed attempts with
+// an exponential backoff.
+type Deliverer struct {
+	Client      *http.Client  // HTTP client used for deliveries.
+	MaxAttempts int           // Attempts before giving up, defaults to 5.
+	Backoff     time.Duration // Wait before the first retry, doubled on each retry.
+	DeadLetters DeadLetterLog // Receives deliveries that exhausted all attempts.
+}
+
+// Deliver posts event to the subscription URL. When every attempt fails
+// the event is recorded on the dead letter log and the last error is
+// returned.
+func (d *Deliverer) Deliver(ctx context.Context, sub Subscription, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	attempts, backoff := d.MaxAttempts, d.Backoff
+	if attempts <= 0 {
+		attempts = defaultMaxAttempts
+	}
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+
+	attempt := 0
+	for {
+		attempt++
+
+		retry, err := d.post(ctx, sub, event, body)
+		if err == nil {
+			return nil
+		}
+
+		if !retry || attempt >= attempts {
+			return d.fail(sub, event, attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return d.fail(sub, event, attempt, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post performs a single delivery attempt and reports whether a failure
+// is worth retrying.
+func (d *Deliverer) post(ctx context.Context, sub Subscription, event Event, body []byte) (bool, error) {
+	req, err := http.NewRequest("POST", sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(event.Type))
+	req.Header.Set(DeliveryHeader, event.ID)
+	if len(sub.Secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(sub.Secret, body))
+	}
+
+	client := d.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return true, err
+	}
+
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusRequestTimeout
+
+	return retry, fmt.Errorf("webhook %s responded %s", sub.URL, strings.TrimSpace(res.Status))
+}
+
+func (d *Deliverer) fail(sub Subscription, event Event, attempts int, err error) error {
+	if d.DeadLetters != nil {
+		d.DeadLetters.Record(DeadLetter{
+			Subscription: sub.ID,
+			URL:          sub.URL,
+			Event:        event,
+			Attempts:     attempts,
+			Error:        err.Error(),
+			FailedAt:     time.Now().UTC(),
+		})
+	}
+
+	return err
+}
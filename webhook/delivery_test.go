@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	signature := Sign("secret", body)
+
+	assert.True(t, Verify("secret", body, signature))
+	assert.False(t, Verify("other", body, signature))
+	assert.False(t, Verify("secret", []byte(`{"id":"2"}`), signature))
+}
+
+func TestDeliverer_Deliver(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "goal", r.Header.Get(EventHeader))
+		assert.Equal(t, "10-goal-1", r.Header.Get(DeliveryHeader))
+		assert.True(t, Verify("secret", body, r.Header.Get(SignatureHeader)))
+
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+	}))
+	defer server.Close()
+
+	d := &Deliverer{Backoff: time.Millisecond}
+	sub := Subscription{ID: "1", URL: server.URL, Secret: "secret"}
+	event := Event{ID: "10-goal-1", Type: EventGoal, Match: football.Match{ID: 10}}
+
+	err := d.Deliver(context.Background(), sub, event)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), attempts)
+}
+
+func TestDeliverer_DeadLetter(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	buf := &bytes.Buffer{}
+	d := &Deliverer{Backoff: time.Millisecond, DeadLetters: NewJSONDeadLetterLog(buf)}
+	sub := Subscription{ID: "1", URL: server.URL}
+	event := Event{ID: "10-final_score", Type: EventFinalScore, Match: football.Match{ID: 10}}
+
+	err := d.Deliver(context.Background(), sub, event)
+
+	assert.True(t, err != nil)
+	assert.Equal(t, int32(1), attempts)
+
+	letter := DeadLetter{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &letter))
+	assert.Equal(t, "1", letter.Subscription)
+	assert.Equal(t, "10-final_score", letter.Event.ID)
+	assert.Equal(t, 1, letter.Attempts)
+}
@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"fmt"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+// EventType identifies the kind of change detected on a match.
+type EventType string
+
+const (
+	EventGoal            EventType = "goal"
+	EventFinalScore      EventType = "final_score"
+	EventLineupPublished EventType = "lineup_published"
+)
+
+// Event represents a change on a match that is delivered to subscribers.
+type Event struct {
+	ID        string          `json:"id"`
+	Type      EventType       `json:"type"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Match     football.Match  `json:"match"`
+	Team      *football.Team  `json:"team,omitempty"`
+	Goal      *football.Goals `json:"goal,omitempty"`
+}
+
+// Detect compares two versions of the same match and returns the events
+// that happened between them. A nil previous version means the match was
+// not seen before, in which case nothing is reported.
+func Detect(prev, next *football.Match) []Event {
+	if prev == nil || next == nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	events := []Event{}
+
+	for _, goal := range newGoals(prev, next) {
+		goal := goal
+		events = append(events, Event{
+			ID:        fmt.Sprintf("%d-%s-%d", next.ID, EventGoal, goal.index),
+			Type:      EventGoal,
+			CreatedAt: now,
+			Match:     *next,
+			Team:      goal.team,
+			Goal:      goal.detail,
+		})
+	}
+
+	for _, team := range []struct{ prev, next *football.Team }{
+		{prev.HomeTeam, next.HomeTeam},
+		{prev.AwayTeam, next.AwayTeam},
+	} {
+		if hasLineup(team.prev) || !hasLineup(team.next) {
+			continue
+		}
+		events = append(events, Event{
+			ID:        fmt.Sprintf("%d-%s-%d", next.ID, EventLineupPublished, team.next.ID),
+			Type:      EventLineupPublished,
+			CreatedAt: now,
+			Match:     *next,
+			Team:      team.next,
+		})
+	}
+
+	if prev.Status != string(football.StatusFinished) && next.Status == string(football.StatusFinished) {
+		events = append(events, Event{
+			ID:        fmt.Sprintf("%d-%s", next.ID, EventFinalScore),
+			Type:      EventFinalScore,
+			CreatedAt: now,
+			Match:     *next,
+		})
+	}
+
+	return events
+}
+
+type goal struct {
+	index  int
+	team   *football.Team
+	detail *football.Goals
+}
+
+// newGoals returns the goals scored between prev and next. Matches with
+// unfolded goals are compared goal by goal, otherwise the full time score
+// is used and the goals carry no detail.
+func newGoals(prev, next *football.Match) []goal {
+	goals := []goal{}
+
+	if len(next.Goals) > 0 {
+		for i := len(prev.Goals); i < len(next.Goals); i++ {
+			detail := next.Goals[i]
+			team := detail.Team
+			goals = append(goals, goal{index: i + 1, team: &team, detail: &detail})
+		}
+		return goals
+	}
+
+	before, after := fullTime(prev), fullTime(next)
+	index := before.HomeTeam + before.AwayTeam
+	for i := before.HomeTeam; i < after.HomeTeam; i++ {
+		index++
+		goals = append(goals, goal{index: index, team: next.HomeTeam})
+	}
+	for i := before.AwayTeam; i < after.AwayTeam; i++ {
+		index++
+		goals = append(goals, goal{index: index, team: next.AwayTeam})
+	}
+
+	return goals
+}
+
+func fullTime(match *football.Match) football.Time {
+	if match.Score == nil {
+		return football.Time{}
+	}
+	return match.Score.FullTime
+}
+
+func hasLineup(team *football.Team) bool {
+	return team != nil && team.Lineup != nil && len(*team.Lineup) > 0
+}
@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"testing"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect_Goals(t *testing.T) {
+	home := &football.Team{ID: 1, Name: "Home"}
+	away := &football.Team{ID: 2, Name: "Away"}
+
+	prev := &football.Match{
+		ID:       10,
+		Status:   string(football.StatusInPlay),
+		HomeTeam: home,
+		AwayTeam: away,
+		Score:    &football.Score{FullTime: football.Time{HomeTeam: 1}},
+	}
+	next := &football.Match{
+		ID:       10,
+		Status:   string(football.StatusInPlay),
+		HomeTeam: home,
+		AwayTeam: away,
+		Score:    &football.Score{FullTime: football.Time{HomeTeam: 1, AwayTeam: 1}},
+	}
+
+	events := Detect(prev, next)
+
+	assert.Len(t, events, 1)
+	assert.Equal(t, "10-goal-2", events[0].ID)
+	assert.Equal(t, EventGoal, events[0].Type)
+	assert.Equal(t, away, events[0].Team)
+	assert.Nil(t, events[0].Goal)
+}
+
+func TestDetect_UnfoldedGoals(t *testing.T) {
+	goal := football.Goals{Minute: 23, Team: football.Team{ID: 2}, Scorer: football.Player{ID: 7}}
+
+	prev := &football.Match{ID: 10, Status: string(football.StatusInPlay)}
+	next := &football.Match{ID: 10, Status: string(football.StatusInPlay), Goals: []football.Goals{goal}}
+
+	events := Detect(prev, next)
+
+	assert.Len(t, events, 1)
+	assert.Equal(t, "10-goal-1", events[0].ID)
+	assert.Equal(t, &goal, events[0].Goal)
+}
+
+func TestDetect_FinalScoreAndLineups(t *testing.T) {
+	lineup := &[]football.Player{{ID: 1}}
+
+	prev := &football.Match{
+		ID:       10,
+		Status:   string(football.StatusPaused),
+		HomeTeam: &football.Team{ID: 1},
+		AwayTeam: &football.Team{ID: 2, Lineup: lineup},
+	}
+	next := &football.Match{
+		ID:       10,
+		Status:   string(football.StatusFinished),
+		HomeTeam: &football.Team{ID: 1, Lineup: lineup},
+		AwayTeam: &football.Team{ID: 2, Lineup: lineup},
+	}
+
+	events := Detect(prev, next)
+
+	assert.Len(t, events, 2)
+	assert.Equal(t, EventLineupPublished, events[0].Type)
+	assert.Equal(t, "10-lineup_published-1", events[0].ID)
+	assert.Equal(t, EventFinalScore, events[1].Type)
+}
+
+func TestDetect_FirstSeen(t *testing.T) {
+	next := &football.Match{ID: 10, Status: string(football.StatusFinished)}
+
+	assert.Nil(t, Detect(nil, next))
+}
@@ -0,0 +1,381 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Subscription represents a webhook registered by a consumer. Empty
+// Competitions, Teams and Events match everything. The Secret is never
+// encoded; the handler returns it once, when the subscription is created.
+type Subscription struct {
+	ID           string      `json:"id"`
+	URL          string      `json:"url"`
+	Secret       string      `json:"-"`
+	Competitions []int       `json:"competitions,omitempty"`
+	Teams        []int       `json:"teams,omitempty"`
+	Events       []EventType `json:"events,omitempty"`
+}
+
+// Matches reports whether event should be delivered to the subscription.
+func (s *Subscription) Matches(event Event) bool {
+	if len(s.Events) > 0 && !containsEvent(s.Events, event.Type) {
+		return false
+	}
+
+	if len(s.Competitions) == 0 && len(s.Teams) == 0 {
+		return true
+	}
+
+	match := event.Match
+	if match.Competition != nil && containsID(s.Competitions, match.Competition.ID) {
+		return true
+	}
+	if match.HomeTeam != nil && containsID(s.Teams, match.HomeTeam.ID) {
+		return true
+	}
+	if match.AwayTeam != nil && containsID(s.Teams, match.AwayTeam.ID) {
+		return true
+	}
+
+	return false
+}
+
+// Hub keeps the registered subscriptions and fans events out to them. It
+// implements http.Handler so consumers can manage their subscriptions:
+//
+//	GET    /subscriptions       list subscriptions
+//	POST   /subscriptions       register a subscription
+//	GET    /subscriptions/{id}  get a subscription
+//	DELETE /subscriptions/{id}  remove a subscription
+//
+// Set Authorize, for example to BearerToken, before exposing the handler:
+// without it anyone reaching the handler can manage every subscription.
+type Hub struct {
+	// Authorize, when set, is called for every request to the handler;
+	// requests it rejects get a 401.
+	Authorize func(r *http.Request) bool
+
+	// Workers is the number of deliveries made at once, defaults to 4.
+	Workers int
+	// QueueSize bounds the deliveries waiting for a worker, defaults to
+	// 1000. Deliveries published to a full queue go to the dead letter
+	// log.
+	QueueSize int
+
+	deliverer *Deliverer
+
+	mu            sync.RWMutex
+	subscriptions map[string]Subscription
+
+	start   sync.Once
+	queue   chan delivery
+	pending sync.WaitGroup
+	ctx     context.Context
+	cancel  context.CancelFunc
+
+	// queueMu orders Publish and Close, so nothing is queued once the
+	// queue was drained.
+	queueMu sync.Mutex
+	closed  bool
+}
+
+// delivery is an event queued for a subscription, or a failed one with
+// err set.
+type delivery struct {
+	sub   Subscription
+	event Event
+	err   error
+}
+
+// errQueueFull is recorded for deliveries published to a full queue.
+var errQueueFull = errors.New("Delivery queue is full")
+
+// errClosed is recorded for deliveries published to a closed hub.
+var errClosed = errors.New("Hub is closed")
+
+// NewHub returns a new Hub delivering events with d. If a nil Deliverer
+// is provided, a Deliverer with default settings will be used.
+func NewHub(d *Deliverer) *Hub {
+	if d == nil {
+		d = &Deliverer{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Hub{deliverer: d, subscriptions: map[string]Subscription{}, ctx: ctx, cancel: cancel}
+}
+
+// Subscribe validates and registers sub, assigning it a new ID.
+func (h *Hub) Subscribe(sub Subscription) (*Subscription, error) {
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return nil, errors.New("Subscription URL must be an absolute http(s) URL")
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	sub.ID = id
+
+	h.mu.Lock()
+	h.subscriptions[sub.ID] = sub
+	h.mu.Unlock()
+
+	return &sub, nil
+}
+
+// Unsubscribe removes the subscription with the given ID and reports
+// whether it existed.
+func (h *Hub) Unsubscribe(id string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, ok := h.subscriptions[id]
+	delete(h.subscriptions, id)
+
+	return ok
+}
+
+// Subscription returns the subscription with the given ID.
+func (h *Hub) Subscription(id string) (*Subscription, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	sub, ok := h.subscriptions[id]
+
+	return &sub, ok
+}
+
+// Subscriptions returns all registered subscriptions ordered by ID.
+func (h *Hub) Subscriptions() []Subscription {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	subs := make([]Subscription, 0, len(h.subscriptions))
+	for _, sub := range h.subscriptions {
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].ID < subs[j].ID })
+
+	return subs
+}
+
+// Publish queues events for every matching subscription and returns
+// without waiting for the deliveries, so a slow or dead endpoint does not
+// hold up the caller. Failed deliveries end up on the dead letter log of
+// the Deliverer.
+func (h *Hub) Publish(ctx context.Context, events ...Event) {
+	h.start.Do(h.startWorkers)
+
+	failed := []delivery{}
+
+	h.queueMu.Lock()
+	for _, sub := range h.Subscriptions() {
+		for _, event := range events {
+			if !sub.Matches(event) {
+				continue
+			}
+
+			if h.closed {
+				failed = append(failed, delivery{sub: sub, event: event, err: errClosed})
+				continue
+			}
+
+			h.pending.Add(1)
+			select {
+			case h.queue <- delivery{sub: sub, event: event}:
+			default:
+				h.pending.Done()
+				failed = append(failed, delivery{sub: sub, event: event, err: errQueueFull})
+			}
+		}
+	}
+	h.queueMu.Unlock()
+
+	for _, d := range failed {
+		h.deliverer.fail(d.sub, d.event, 0, d.err)
+	}
+}
+
+// Wait blocks until every queued delivery is done.
+func (h *Hub) Wait() {
+	h.pending.Wait()
+}
+
+// Close stops the workers. Deliveries in progress or still queued, and
+// those published afterwards, are recorded on the dead letter log.
+func (h *Hub) Close() {
+	// Synchronizes with startWorkers, and keeps it from running later.
+	h.start.Do(func() {})
+
+	h.queueMu.Lock()
+	h.closed = true
+	h.cancel()
+
+	drained := []delivery{}
+	for len(h.queue) > 0 {
+		select {
+		case d := <-h.queue:
+			drained = append(drained, d)
+		default:
+		}
+	}
+	h.queueMu.Unlock()
+
+	for _, d := range drained {
+		h.deliverer.fail(d.sub, d.event, 0, h.ctx.Err())
+		h.pending.Done()
+	}
+}
+
+func (h *Hub) startWorkers() {
+	workers, size := h.Workers, h.QueueSize
+	if workers <= 0 {
+		workers = 4
+	}
+	if size <= 0 {
+		size = 1000
+	}
+
+	h.queue = make(chan delivery, size)
+	for i := 0; i < workers; i++ {
+		go h.work()
+	}
+}
+
+func (h *Hub) work() {
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case d := <-h.queue:
+			h.deliverer.Deliver(h.ctx, d.sub, d.event)
+			h.pending.Done()
+		}
+	}
+}
+
+// BearerToken returns an authorization function for Hub.Authorize that
+// accepts requests carrying "Authorization: Bearer <token>".
+func BearerToken(token string) func(r *http.Request) bool {
+	expected := []byte("Bearer " + token)
+
+	return func(r *http.Request) bool {
+		return subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) == 1
+	}
+}
+
+// subscriptionRequest is the body of a POST, the only place the secret
+// is read from.
+type subscriptionRequest struct {
+	Subscription
+	Secret string `json:"secret"`
+}
+
+// createdSubscription is the body answering a POST, the only place the
+// secret is written to.
+type createdSubscription struct {
+	*Subscription
+	Secret string `json:"secret,omitempty"`
+}
+
+// ServeHTTP handles the subscription management endpoints.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.Authorize != nil && !h.Authorize(r) {
+		writeError(w, http.StatusUnauthorized, errors.New(http.StatusText(http.StatusUnauthorized)))
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+
+	switch {
+	case path == "subscriptions" && r.Method == "GET":
+		writeJSON(w, http.StatusOK, h.Subscriptions())
+
+	case path == "subscriptions" && r.Method == "POST":
+		body := subscriptionRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		sub := body.Subscription
+		sub.Secret = body.Secret
+
+		created, err := h.Subscribe(sub)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, createdSubscription{Subscription: created, Secret: created.Secret})
+
+	case strings.HasPrefix(path, "subscriptions/") && r.Method == "GET":
+		sub, ok := h.Subscription(strings.TrimPrefix(path, "subscriptions/"))
+		if !ok {
+			writeError(w, http.StatusNotFound, errors.New("Subscription not found"))
+			return
+		}
+		writeJSON(w, http.StatusOK, sub)
+
+	case strings.HasPrefix(path, "subscriptions/") && r.Method == "DELETE":
+		if !h.Unsubscribe(strings.TrimPrefix(path, "subscriptions/")) {
+			writeError(w, http.StatusNotFound, errors.New("Subscription not found"))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case path == "subscriptions" || strings.HasPrefix(path, "subscriptions/"):
+		writeError(w, http.StatusMethodNotAllowed, errors.New(http.StatusText(http.StatusMethodNotAllowed)))
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func containsID(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsEvent(events []EventType, event EventType) bool {
+	for _, v := range events {
+		if v == event {
+			return true
+		}
+	}
+	return false
+}
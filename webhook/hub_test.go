@@ -0,0 +1,248 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscription_Matches(t *testing.T) {
+	event := Event{
+		Type: EventGoal,
+		Match: football.Match{
+			Competition: &football.Competition{ID: 2021},
+			HomeTeam:    &football.Team{ID: 57},
+			AwayTeam:    &football.Team{ID: 61},
+		},
+	}
+
+	tests := []struct {
+		sub      Subscription
+		expected bool
+	}{
+		{Subscription{}, true},
+		{Subscription{Competitions: []int{2021}}, true},
+		{Subscription{Competitions: []int{2014}}, false},
+		{Subscription{Teams: []int{61}}, true},
+		{Subscription{Competitions: []int{2014}, Teams: []int{57}}, true},
+		{Subscription{Events: []EventType{EventFinalScore}}, false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.sub.Matches(event))
+	}
+}
+
+func TestHub_ServeHTTP(t *testing.T) {
+	hub := NewHub(nil)
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	res, err := http.Post(server.URL+"/subscriptions", "application/json", strings.NewReader(`{
+		"url": "https://example.com/hook",
+		"secret": "secret",
+		"teams": [57]
+	}`))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+
+	body := map[string]interface{}{}
+	json.NewDecoder(res.Body).Decode(&body)
+	res.Body.Close()
+
+	// The secret is returned once, on creation.
+	assert.Equal(t, "secret", body["secret"])
+	assert.NotEmpty(t, body["id"])
+
+	created := hub.Subscriptions()[0]
+	assert.Equal(t, body["id"], created.ID)
+	assert.Equal(t, "secret", created.Secret)
+	assert.Equal(t, []int{57}, created.Teams)
+
+	for _, path := range []string{"/subscriptions", "/subscriptions/" + created.ID} {
+		res, err = http.Get(server.URL + path)
+		assert.Nil(t, err)
+		listed, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, string(listed), created.ID)
+		assert.NotContains(t, string(listed), "secret")
+	}
+
+	res, err = http.Post(server.URL+"/subscriptions", "application/json", strings.NewReader(`{"url": "ftp://example.com"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	req, _ := http.NewRequest("DELETE", server.URL+"/subscriptions/"+created.ID, nil)
+	res, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	assert.Empty(t, hub.Subscriptions())
+}
+
+func TestHub_Authorize(t *testing.T) {
+	hub := NewHub(nil)
+	hub.Authorize = BearerToken("admin")
+	server := httptest.NewServer(hub)
+	defer server.Close()
+
+	tests := []struct {
+		header string
+		status int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer other", http.StatusUnauthorized},
+		{"Bearer admin", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		req, _ := http.NewRequest("GET", server.URL+"/subscriptions", nil)
+		if test.header != "" {
+			req.Header.Set("Authorization", test.header)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		res.Body.Close()
+		assert.Equal(t, test.status, res.StatusCode, test.header)
+	}
+}
+
+func TestHub_Publish(t *testing.T) {
+	var mu sync.Mutex
+	received := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, r.URL.Path+" "+r.Header.Get(DeliveryHeader))
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	hub := NewHub(nil)
+	hub.Subscribe(Subscription{URL: server.URL + "/pl", Competitions: []int{2021}})
+	hub.Subscribe(Subscription{URL: server.URL + "/bsa", Competitions: []int{2013}})
+
+	hub.Publish(context.Background(), Event{
+		ID:    "10-final_score",
+		Type:  EventFinalScore,
+		Match: football.Match{ID: 10, Competition: &football.Competition{ID: 2021}},
+	})
+	hub.Wait()
+
+	assert.Equal(t, []string{"/pl 10-final_score"}, received)
+}
+
+func TestHub_PublishDoesNotWait(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+
+	letters := &memoryDeadLetters{}
+	hub := NewHub(&Deliverer{DeadLetters: letters})
+	hub.Workers = 1
+	hub.QueueSize = 1
+	hub.Subscribe(Subscription{URL: server.URL})
+
+	event := Event{ID: "10-final_score", Type: EventFinalScore}
+
+	done := make(chan struct{})
+	go func() {
+		// The first delivery hangs on the endpoint, the second waits in
+		// the queue and the third finds it full.
+		hub.Publish(context.Background(), event)
+		time.Sleep(20 * time.Millisecond)
+		hub.Publish(context.Background(), event, event)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Publish waited for the deliveries")
+	}
+
+	assert.Equal(t, []string{errQueueFull.Error()}, letters.errors())
+
+	close(release)
+	hub.Wait()
+	hub.Close()
+
+	hub.Publish(context.Background(), event)
+	assert.Equal(t, []string{errQueueFull.Error(), errClosed.Error()}, letters.errors())
+}
+
+func TestHub_PublishWhileClosing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	letters := &memoryDeadLetters{}
+	hub := NewHub(&Deliverer{DeadLetters: letters})
+	hub.Subscribe(Subscription{URL: server.URL})
+
+	event := Event{ID: "10-final_score", Type: EventFinalScore}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hub.Publish(context.Background(), event, event)
+		}()
+	}
+	hub.Close()
+	wg.Wait()
+
+	done := make(chan struct{})
+	go func() {
+		hub.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait hung on deliveries published while closing")
+	}
+
+	hub.Publish(context.Background(), event)
+	recorded := letters.errors()
+	assert.Equal(t, errClosed.Error(), recorded[len(recorded)-1])
+}
+
+// memoryDeadLetters keeps the dead letters in memory.
+type memoryDeadLetters struct {
+	mu      sync.Mutex
+	letters []DeadLetter
+}
+
+func (m *memoryDeadLetters) Record(letter DeadLetter) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.letters = append(m.letters, letter)
+	return nil
+}
+
+func (m *memoryDeadLetters) errors() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	errors := []string{}
+	for _, letter := range m.letters {
+		errors = append(errors, letter.Error)
+	}
+	return errors
+}
@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+)
+
+const (
+	defaultInterval       = time.Minute
+	defaultLineupWindow   = time.Hour
+	defaultLineupAttempts = 5
+)
+
+// Watcher polls the matches endpoint and publishes the detected events on
+// a Hub. A single Watcher serves every subscription, so the API quota is
+// spent once regardless of the number of consumers.
+//
+// List responses carry no lineups, so live matches, and matches kicking
+// off within LineupWindow, are fetched one by one with MatchService.Find
+// until both lineups are known, at most LineupAttempts times per match as
+// some plans never return them.
+type Watcher struct {
+	Client       *football.Client
+	Hub          *Hub
+	Filters      *football.MatchesFiltersOptions // Filters passed to MatchService.List.
+	Interval     time.Duration                   // Time between polls, defaults to one minute.
+	LineupWindow time.Duration                   // Time before kick-off lineups are looked for, defaults to one hour.
+	// LineupAttempts bounds the MatchService.Find calls made for the
+	// lineups of a match, defaults to 5.
+	LineupAttempts int
+
+	seen     map[int]football.Match
+	attempts map[int]int
+}
+
+// Poll fetches the matches once, publishes the events found since the
+// previous poll and returns them. Matches seen for the first time are
+// only recorded.
+func (w *Watcher) Poll(ctx context.Context) ([]Event, error) {
+	list, err := w.Client.Matches.List(ctx, w.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	if w.seen == nil {
+		w.seen = map[int]football.Match{}
+		w.attempts = map[int]int{}
+	}
+
+	now := time.Now()
+	events := []Event{}
+	for _, match := range list.Matches {
+		match := match
+		prev, seen := w.seen[match.ID]
+		if seen {
+			carryLineups(&prev, &match)
+		}
+
+		if w.awaitsLineups(&match, now) {
+			w.fetchLineups(ctx, &match)
+		}
+
+		if seen {
+			events = append(events, Detect(&prev, &match)...)
+		}
+		w.seen[match.ID] = match
+	}
+
+	if len(events) > 0 {
+		w.Hub.Publish(ctx, events...)
+	}
+
+	return events, nil
+}
+
+// awaitsLineups reports whether match is live or about to kick off,
+// misses a lineup and has attempts left.
+func (w *Watcher) awaitsLineups(match *football.Match, now time.Time) bool {
+	if hasLineup(match.HomeTeam) && hasLineup(match.AwayTeam) {
+		return false
+	}
+
+	attempts := w.LineupAttempts
+	if attempts <= 0 {
+		attempts = defaultLineupAttempts
+	}
+	if w.attempts[match.ID] >= attempts {
+		return false
+	}
+
+	switch football.Status(match.Status) {
+	case football.StatusLive, football.StatusInPlay, football.StatusPaused:
+		return true
+	case football.StatusScheduled, football.StatusTimed:
+		window := w.LineupWindow
+		if window <= 0 {
+			window = defaultLineupWindow
+		}
+
+		kickoff, err := time.Parse(time.RFC3339, match.UtcDate)
+		return err == nil && kickoff.Sub(now) <= window
+	}
+
+	return false
+}
+
+// fetchLineups fills the lineups of match from MatchService.Find. Errors
+// are left for the next poll to retry, and count as an attempt.
+func (w *Watcher) fetchLineups(ctx context.Context, match *football.Match) {
+	w.attempts[match.ID]++
+
+	full, err := w.Client.Matches.Find(ctx, strconv.Itoa(match.ID))
+	if err != nil {
+		return
+	}
+
+	match.HomeTeam = withLineup(match.HomeTeam, full.Match.HomeTeam)
+	match.AwayTeam = withLineup(match.AwayTeam, full.Match.AwayTeam)
+}
+
+// carryLineups copies the lineups already known from prev to next, as
+// the list responses never carry them.
+func carryLineups(prev, next *football.Match) {
+	next.HomeTeam = withLineup(next.HomeTeam, prev.HomeTeam)
+	next.AwayTeam = withLineup(next.AwayTeam, prev.AwayTeam)
+}
+
+// withLineup returns a copy of team with the lineup and bench of from,
+// when team has no lineup and from has one.
+func withLineup(team, from *football.Team) *football.Team {
+	if team == nil || hasLineup(team) || !hasLineup(from) {
+		return team
+	}
+
+	merged := *team
+	merged.Lineup, merged.Bench = from.Lineup, from.Bench
+	return &merged
+}
+
+// Run polls until ctx is done. Errors from a single poll do not stop the
+// watcher; they are retried on the next tick.
+func (w *Watcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		w.Poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	football "github.com/matheustex/football-data-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher_Poll(t *testing.T) {
+	t.Setenv("FOOTBALL_API_TOKEN", "x")
+
+	scores := []string{`"homeTeam": 0, "awayTeam": 0`, `"homeTeam": 1, "awayTeam": 0`}
+	polls := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/matches", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2021", r.URL.Query().Get("competitions"))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{
+			"count": 1,
+			"matches": [
+				{
+					"id": 10,
+					"status": "IN_PLAY",
+					"competition": {"id": 2021},
+					"homeTeam": {"id": 57},
+					"awayTeam": {"id": 61},
+					"score": {"fullTime": {%s}}
+				}
+			]
+		}`, scores[polls])
+		polls++
+	})
+	mux.HandleFunc("/v2/matches/10", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"match": {"id": 10}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := football.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/v2")
+
+	watcher := &Watcher{
+		Client:  client,
+		Hub:     NewHub(nil),
+		Filters: &football.MatchesFiltersOptions{Competitions: "2021"},
+	}
+
+	events, err := watcher.Poll(context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, events)
+
+	events, err = watcher.Poll(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "10-goal-1", events[0].ID)
+}
+
+func TestWatcher_PollLineups(t *testing.T) {
+	t.Setenv("FOOTBALL_API_TOKEN", "x")
+
+	kickoff := time.Now().Add(30 * time.Minute).UTC().Format(time.RFC3339)
+	finds := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/matches", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"count": 2, "matches": [
+			{"id": 10, "status": "SCHEDULED", "utcDate": %q, "homeTeam": {"id": 57}, "awayTeam": {"id": 61}},
+			{"id": 11, "status": "SCHEDULED", "utcDate": "2099-01-01T00:00:00Z", "homeTeam": {"id": 1}, "awayTeam": {"id": 2}}
+		]}`, kickoff)
+	})
+	mux.HandleFunc("/v2/matches/10", func(w http.ResponseWriter, r *http.Request) {
+		finds++
+		lineup := `"lineup": [{"id": 1}]`
+		if finds == 1 {
+			lineup = `"lineup": []`
+		}
+		fmt.Fprintf(w, `{"match": {"id": 10, "homeTeam": {"id": 57, %s}, "awayTeam": {"id": 61, %s}}}`, lineup, lineup)
+	})
+	mux.HandleFunc("/v2/matches/11", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("match 11 is far from kick-off")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := football.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/v2")
+
+	watcher := &Watcher{
+		Client: client,
+		Hub:    NewHub(nil),
+	}
+	ctx := context.Background()
+
+	events, err := watcher.Poll(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, events)
+
+	events, err = watcher.Poll(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "10-lineup_published-57", events[0].ID)
+	assert.Equal(t, "10-lineup_published-61", events[1].ID)
+
+	// Known lineups are kept without fetching the match again.
+	events, err = watcher.Poll(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, 2, finds)
+}
+
+func TestWatcher_PollLineupsAttempts(t *testing.T) {
+	t.Setenv("FOOTBALL_API_TOKEN", "x")
+
+	finds := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/matches", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 1, "matches": [{"id": 10, "status": "IN_PLAY", "homeTeam": {"id": 57}, "awayTeam": {"id": 61}}]}`)
+	})
+	mux.HandleFunc("/v2/matches/10", func(w http.ResponseWriter, r *http.Request) {
+		finds++
+		fmt.Fprint(w, `{"match": {"id": 10, "homeTeam": {"id": 57}, "awayTeam": {"id": 61}}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := football.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/v2")
+
+	watcher := &Watcher{
+		Client:         client,
+		Hub:            NewHub(nil),
+		LineupAttempts: 3,
+	}
+
+	for i := 0; i < 5; i++ {
+		_, err := watcher.Poll(context.Background())
+		assert.Nil(t, err)
+	}
+
+	// The plan never returns lineups: the match is only looked up 3 times.
+	assert.Equal(t, 3, finds)
+}